@@ -0,0 +1,107 @@
+package swiftcsv
+
+import (
+	"bytes"
+	"io"
+)
+
+// NeedsHeader reports whether the CSV content behind r is still empty, meaning a header should
+// be written before appending records. Content consisting only of whitespace, such as a lone
+// trailing newline, counts as empty. r is scanned from the start and restored to its original
+// offset before NeedsHeader returns.
+func NeedsHeader(r io.ReadSeeker) (bool, error) {
+	if r == nil {
+		return false, errNilSource
+	}
+
+	orig, err := r.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return false, err
+	}
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return false, err
+	}
+
+	empty := true
+	buf := make([]byte, defaultBufferSize)
+scan:
+	for {
+		n, err := r.Read(buf)
+		for _, b := range buf[:n] {
+			switch b {
+			case ' ', '\t', '\r', '\n':
+			default:
+				empty = false
+				break scan
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return false, err
+		}
+	}
+
+	if _, err := r.Seek(orig, io.SeekStart); err != nil {
+		return false, err
+	}
+	return empty, nil
+}
+
+// EnsureLeadingNewline inspects the existing CSV content behind r and, when it is non-empty and
+// does not end with a line break, writes the configured record terminator so the next record
+// does not join the last existing one. When RecordTerminator is set, the content must end with
+// it instead of a line break. Call it before writing the first appended record. r is
+// restored to its original offset before EnsureLeadingNewline returns.
+func (w *Writer) EnsureLeadingNewline(r io.ReadSeeker) error {
+	if w == nil {
+		return errNilWriter
+	}
+	if r == nil {
+		return errNilSource
+	}
+	if w.dst == nil {
+		return errWriterNoTarget
+	}
+	if w.err != nil {
+		return w.err
+	}
+
+	orig, err := r.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+	size, err := r.Seek(0, io.SeekEnd)
+	if err != nil {
+		return err
+	}
+
+	terminated := true
+	if size > 0 {
+		n := int64(1)
+		if len(w.RecordTerminator) > 0 {
+			n = min(int64(len(w.RecordTerminator)), size)
+		}
+		if _, err := r.Seek(-n, io.SeekEnd); err != nil {
+			return err
+		}
+		last := make([]byte, n)
+		if _, err := io.ReadFull(r, last); err != nil {
+			return err
+		}
+		if len(w.RecordTerminator) > 0 {
+			terminated = bytes.Equal(last, w.RecordTerminator)
+		} else {
+			terminated = last[0] == '\n' || last[0] == '\r'
+		}
+	}
+
+	if _, err := r.Seek(orig, io.SeekStart); err != nil {
+		return err
+	}
+	if terminated {
+		return nil
+	}
+	return w.writeTerminator()
+}
@@ -0,0 +1,101 @@
+package swiftcsv
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestNeedsHeader(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input string
+		want  bool
+	}{
+		{name: "empty", input: "", want: true},
+		{name: "trailingNewlineOnly", input: "\n", want: true},
+		{name: "whitespaceOnly", input: " \r\n\t\n", want: true},
+		{name: "headerOnly", input: "id,name\n", want: false},
+		{name: "headerWithoutNewline", input: "id,name", want: false},
+		{name: "populated", input: "id,name\n1,alpha\n2,beta\n", want: false},
+		{name: "leadingBlankLines", input: strings.Repeat("\n", 2000) + "id\n", want: false},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			src := strings.NewReader(tc.input)
+			if _, err := src.Seek(0, io.SeekEnd); err != nil {
+				t.Fatalf("Seek() error = %v", err)
+			}
+
+			got, err := NeedsHeader(src)
+			if err != nil {
+				t.Fatalf("NeedsHeader() error = %v", err)
+			}
+			if got != tc.want {
+				t.Fatalf("NeedsHeader() = %v, want %v", got, tc.want)
+			}
+			if pos, _ := src.Seek(0, io.SeekCurrent); pos != int64(len(tc.input)) {
+				t.Fatalf("NeedsHeader() left offset %d, want %d", pos, len(tc.input))
+			}
+		})
+	}
+}
+
+func TestWriterEnsureLeadingNewline(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		existing   string
+		crlf       bool
+		terminator string
+		want       string
+	}{
+		{name: "empty", existing: "", want: "3,c\n"},
+		{name: "endsWithLF", existing: "id,v\n1,a\n", want: "3,c\n"},
+		{name: "endsWithCRLF", existing: "id,v\r\n1,a\r\n", crlf: true, want: "3,c\r\n"},
+		{name: "missingNewline", existing: "id,v\n1,a", want: "\n3,c\n"},
+		{name: "missingNewlineCRLF", existing: "id,v\r\n1,a", crlf: true, want: "\r\n3,c\r\n"},
+		{name: "endsWithTerminator", existing: "id,v\x1e1,a\x1e", terminator: "\x1e", want: "3,c\x1e"},
+		{name: "missingTerminator", existing: "id,v\x1e1,a", terminator: "\x1e", want: "\x1e3,c\x1e"},
+		{name: "endsWithMultiByteTerminator", existing: "1,a<EOR>\n", terminator: "<EOR>\n", want: "3,c<EOR>\n"},
+		{name: "newlineIsNotTerminator", existing: "1,a\n", terminator: "<EOR>\n", want: "<EOR>\n3,c<EOR>\n"},
+		{name: "shorterThanTerminator", existing: "\n", terminator: "<EOR>\n", want: "<EOR>\n3,c<EOR>\n"},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			existing := strings.NewReader(tc.existing)
+			var buf bytes.Buffer
+			w := NewWriter(&buf)
+			w.UseCRLF = tc.crlf
+			w.RecordTerminator = []byte(tc.terminator)
+
+			if err := w.EnsureLeadingNewline(existing); err != nil {
+				t.Fatalf("EnsureLeadingNewline() error = %v", err)
+			}
+			if pos, _ := existing.Seek(0, io.SeekCurrent); pos != 0 {
+				t.Fatalf("EnsureLeadingNewline() left offset %d, want 0", pos)
+			}
+			if err := w.Write([]string{"3", "c"}); err != nil {
+				t.Fatalf("Write() error = %v", err)
+			}
+			if err := w.Flush(); err != nil {
+				t.Fatalf("Flush() error = %v", err)
+			}
+			if got := buf.String(); got != tc.want {
+				t.Fatalf("appended output = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package swiftcsv
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var errDecodeTarget = errors.New("swiftcsv: decode target must be a non-nil pointer to a struct")
+
+// DecodePositional reads the next record into the struct pointed to by v, assigning fields to
+// exported struct fields in declaration order. Fields tagged `csv:"-"` and unexported fields
+// are skipped and consume no column. Columns beyond the struct are ignored and struct fields
+// beyond the record keep their previous values, unless their tag supplies a default as
+// described for Decode. Strings, signed and unsigned integers, floats, and bools are supported;
+// a failed conversion returns a *ParseError whose Column is the 1-based index of the offending
+// field.
+func (r *Reader) DecodePositional(v any) error {
+	if r == nil {
+		return io.EOF
+	}
+	target, err := decodeTarget(v)
+	if err != nil {
+		return err
+	}
+	record, err := r.Read()
+	if err != nil {
+		return err
+	}
+
+	typ := target.Type()
+	col := 0
+	for i := 0; i < typ.NumField(); i++ {
+		sf := typ.Field(i)
+		if !sf.IsExported() || sf.Tag.Get("csv") == "-" {
+			continue
+		}
+		_, def, hasDef := parseFieldTag(sf)
+		value, ok := "", false
+		if col < len(record) {
+			value, ok = record[col], true
+		}
+		col++
+		if value == "" && hasDef {
+			if err := setDefault(target.Field(i), sf, def); err != nil {
+				return err
+			}
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if err := setField(target.Field(i), value); err != nil {
+			return &ParseError{Line: r.recordLine, Column: col, Err: err}
+		}
+	}
+	return nil
+}
+
+// Decode reads the next record into the struct pointed to by v, matching exported struct
+// fields to header columns by the name in their `csv` tag, or by field name when the tag has
+// none. When no header is in effect the next record is consumed as the header first, as with
+// ReadMap. Fields tagged `csv:"-"`, unexported fields, and columns without a matching field
+// are skipped.
+//
+// A tag option of the form `csv:"age,default=18"` supplies a value used when the column is
+// absent from the header or the field is empty; everything after "default=" is the value, so
+// it may contain commas. Defaults go through the same conversion as field data. Without a
+// default, fields whose column is absent keep their previous values. A failed conversion
+// returns a *ParseError whose Column is the 1-based index of the offending column.
+func (r *Reader) Decode(v any) error {
+	if r == nil {
+		return io.EOF
+	}
+	target, err := decodeTarget(v)
+	if err != nil {
+		return err
+	}
+	if r.header == nil {
+		if _, err := r.ReadHeader(); err != nil {
+			return err
+		}
+	}
+	record, err := r.Read()
+	if err != nil {
+		return err
+	}
+
+	typ := target.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		sf := typ.Field(i)
+		if !sf.IsExported() || sf.Tag.Get("csv") == "-" {
+			continue
+		}
+		name, def, hasDef := parseFieldTag(sf)
+		col := r.ColumnIndex(name)
+		value := ""
+		if col >= 0 && col < len(record) {
+			value = record[col]
+		}
+		if value == "" && hasDef {
+			if err := setDefault(target.Field(i), sf, def); err != nil {
+				return err
+			}
+			continue
+		}
+		if col < 0 || col >= len(record) {
+			continue
+		}
+		if err := setField(target.Field(i), value); err != nil {
+			return &ParseError{Line: r.recordLine, Column: col + 1, Err: err}
+		}
+	}
+	return nil
+}
+
+// parseFieldTag returns the column name of sf and the default value from its `csv` tag.
+func parseFieldTag(sf reflect.StructField) (name, def string, hasDef bool) {
+	name, opts, _ := strings.Cut(sf.Tag.Get("csv"), ",")
+	if name == "" {
+		name = sf.Name
+	}
+	for opts != "" {
+		if def, hasDef = strings.CutPrefix(opts, "default="); hasDef {
+			break
+		}
+		_, opts, _ = strings.Cut(opts, ",")
+	}
+	return name, def, hasDef
+}
+
+// setDefault stores the tag default def in dst.
+func setDefault(dst reflect.Value, sf reflect.StructField, def string) error {
+	if err := setField(dst, def); err != nil {
+		return fmt.Errorf("swiftcsv: default for field %s: %w", sf.Name, err)
+	}
+	return nil
+}
+
+// decodeTarget returns the struct value addressed by v.
+func decodeTarget(v any) (reflect.Value, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return reflect.Value{}, errDecodeTarget
+	}
+	return rv.Elem(), nil
+}
+
+// setField converts s to the kind of dst and stores it. The field string is copied so the
+// decoded value does not alias reader storage under ReuseRecord.
+func setField(dst reflect.Value, s string) error {
+	switch dst.Kind() {
+	case reflect.String:
+		dst.SetString(string([]byte(s)))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, dst.Type().Bits())
+		if err != nil {
+			return err
+		}
+		dst.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, dst.Type().Bits())
+		if err != nil {
+			return err
+		}
+		dst.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, dst.Type().Bits())
+		if err != nil {
+			return err
+		}
+		dst.SetFloat(f)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		dst.SetBool(b)
+	default:
+		return fmt.Errorf("swiftcsv: unsupported field type %s", dst.Type())
+	}
+	return nil
+}
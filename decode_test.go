@@ -0,0 +1,172 @@
+package swiftcsv
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+type positionalRow struct {
+	ID      int64
+	Name    string
+	Note    string `csv:"-"`
+	Price   float64
+	Active  bool
+	Qty     uint8
+	private string
+}
+
+func TestReaderDecodePositional(t *testing.T) {
+	t.Parallel()
+
+	r := NewReader(strings.NewReader("7,widget,9.5,true,12,extra\n8,gadget,0.25,false,3,\n"))
+	r.ReuseRecord = true
+
+	var first positionalRow
+	first.Note = "kept"
+	if err := r.DecodePositional(&first); err != nil {
+		t.Fatalf("DecodePositional() error = %v", err)
+	}
+	var second positionalRow
+	if err := r.DecodePositional(&second); err != nil {
+		t.Fatalf("DecodePositional() error = %v", err)
+	}
+
+	want := positionalRow{ID: 7, Name: "widget", Note: "kept", Price: 9.5, Active: true, Qty: 12}
+	if first != want {
+		t.Fatalf("DecodePositional() = %+v, want %+v", first, want)
+	}
+	want = positionalRow{ID: 8, Name: "gadget", Price: 0.25, Qty: 3}
+	if second != want {
+		t.Fatalf("DecodePositional() = %+v, want %+v", second, want)
+	}
+}
+
+func TestReaderDecodePositionalErrors(t *testing.T) {
+	t.Parallel()
+
+	t.Run("conversion", func(t *testing.T) {
+		t.Parallel()
+
+		r := NewReader(strings.NewReader("1,a,x,true,1\n"))
+		var row positionalRow
+		err := r.DecodePositional(&row)
+		var perr *ParseError
+		if !errors.As(err, &perr) {
+			t.Fatalf("DecodePositional() error = %v, want *ParseError", err)
+		}
+		if perr.Line != 1 || perr.Column != 3 {
+			t.Fatalf("ParseError at %d:%d, want 1:3", perr.Line, perr.Column)
+		}
+		if !errors.Is(err, strconv.ErrSyntax) {
+			t.Fatalf("DecodePositional() error = %v, want strconv.ErrSyntax", err)
+		}
+	})
+
+	t.Run("overflow", func(t *testing.T) {
+		t.Parallel()
+
+		r := NewReader(strings.NewReader("1,a,1,true,256\n"))
+		var row positionalRow
+		if err := r.DecodePositional(&row); !errors.Is(err, strconv.ErrRange) {
+			t.Fatalf("DecodePositional() error = %v, want strconv.ErrRange", err)
+		}
+	})
+
+	t.Run("target", func(t *testing.T) {
+		t.Parallel()
+
+		r := NewReader(strings.NewReader("1\n"))
+		var row positionalRow
+		for _, v := range []any{nil, row, (*positionalRow)(nil), new(int)} {
+			if err := r.DecodePositional(v); !errors.Is(err, errDecodeTarget) {
+				t.Fatalf("DecodePositional(%T) error = %v, want errDecodeTarget", v, err)
+			}
+		}
+	})
+}
+
+type headerRow struct {
+	Name    string  `csv:"name"`
+	Age     int     `csv:"age,default=18"`
+	Country string  `csv:"country,default=unknown, n/a"`
+	Score   float64 `csv:"score,omitempty,default=1.5"`
+	Email   string
+	Skipped string `csv:"-"`
+}
+
+func TestReaderDecode(t *testing.T) {
+	t.Parallel()
+
+	r := NewReader(strings.NewReader("Email,age,name,extra\nann@example.com,42,Ann,x\n,,Bob,\n"))
+	var rows []headerRow
+	for {
+		row := headerRow{Skipped: "kept"}
+		err := r.Decode(&row)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Decode() error = %v", err)
+		}
+		rows = append(rows, row)
+	}
+
+	want := []headerRow{
+		{Name: "Ann", Age: 42, Country: "unknown, n/a", Score: 1.5, Email: "ann@example.com", Skipped: "kept"},
+		{Name: "Bob", Age: 18, Country: "unknown, n/a", Score: 1.5, Skipped: "kept"},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Fatalf("Decode() = %+v, want %+v", rows, want)
+	}
+}
+
+func TestReaderDecodeDefaultErrors(t *testing.T) {
+	t.Parallel()
+
+	r := NewReader(strings.NewReader("name,age\nAnn,old\n"))
+	var row headerRow
+	err := r.Decode(&row)
+	var perr *ParseError
+	if !errors.As(err, &perr) || perr.Line != 2 || perr.Column != 2 {
+		t.Fatalf("Decode() error = %v, want *ParseError at 2:2", err)
+	}
+
+	type badDefault struct {
+		N int `csv:"n,default=many"`
+	}
+	var bad badDefault
+	r = NewReader(strings.NewReader("m\n1\n"))
+	if err := r.Decode(&bad); err == nil || !strings.Contains(err.Error(), "default for field N") {
+		t.Fatalf("Decode() error = %v, want default conversion error", err)
+	}
+}
+
+func TestReaderDecodePositionalDefaults(t *testing.T) {
+	t.Parallel()
+
+	type row struct {
+		ID    int
+		Label string `csv:",default=none"`
+		Qty   int    `csv:"qty,default=1"`
+	}
+	r := NewReader(strings.NewReader("1,,5\n2,x,\n"))
+	var got []row
+	for {
+		var v row
+		err := r.DecodePositional(&v)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("DecodePositional() error = %v", err)
+		}
+		got = append(got, v)
+	}
+	if want := []row{{1, "none", 5}, {2, "x", 1}}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("DecodePositional() = %+v, want %+v", got, want)
+	}
+}
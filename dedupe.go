@@ -0,0 +1,38 @@
+package swiftcsv
+
+// DedupeWriter forwards records to a Writer, dropping any record whose key column matches a
+// record written earlier. Every distinct key is retained for the lifetime of the DedupeWriter,
+// so memory grows with the number of unique keys: roughly the key bytes plus a few dozen bytes
+// of map overhead per key.
+type DedupeWriter struct {
+	w        *Writer
+	keyIndex int
+	seen     map[string]struct{}
+}
+
+// NewDedupeWriter creates a DedupeWriter that writes to w and keys records on the field at
+// keyIndex. Records too short to have that field share the empty key.
+func NewDedupeWriter(w *Writer, keyIndex int) *DedupeWriter {
+	if w == nil {
+		panic(errNilWriter.Error())
+	}
+	return &DedupeWriter{w: w, keyIndex: keyIndex, seen: make(map[string]struct{})}
+}
+
+// Write writes record unless its key has been written before and reports whether it was
+// written. A record that fails to write does not mark its key as seen.
+func (d *DedupeWriter) Write(record []string) (bool, error) {
+	if d == nil {
+		return false, errNilWriter
+	}
+	key := Field(record, d.keyIndex)
+	if _, dup := d.seen[key]; dup {
+		return false, nil
+	}
+	if err := d.w.Write(record); err != nil {
+		return false, err
+	}
+	// Copy the key so it does not alias reader storage reused by ReuseRecord.
+	d.seen[string([]byte(key))] = struct{}{}
+	return true, nil
+}
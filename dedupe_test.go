@@ -0,0 +1,61 @@
+package swiftcsv
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestDedupeWriter(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	dw := NewDedupeWriter(w, 1)
+
+	tests := []struct {
+		record []string
+		want   bool
+	}{
+		{record: []string{"a", "k1", "first"}, want: true},
+		{record: []string{"b", "k2", "unique"}, want: true},
+		{record: []string{"c", "k1", "duplicate"}, want: false},
+		{record: []string{"d"}, want: true},
+		{record: []string{"e", ""}, want: false},
+		{record: []string{"f", "k3"}, want: true},
+		{record: []string{"g", "k2"}, want: false},
+	}
+	for i, tc := range tests {
+		got, err := dw.Write(tc.record)
+		if err != nil {
+			t.Fatalf("Write() record %d error = %v", i, err)
+		}
+		if got != tc.want {
+			t.Fatalf("Write() record %d = %v, want %v", i, got, tc.want)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	want := "a,k1,first\nb,k2,unique\nd\nf,k3\n"
+	if got := buf.String(); got != want {
+		t.Fatalf("unexpected output:\n got: %q\nwant: %q", got, want)
+	}
+}
+
+func TestDedupeWriterError(t *testing.T) {
+	t.Parallel()
+
+	w := NewWriter(&bytes.Buffer{})
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	dw := NewDedupeWriter(w, 0)
+	if ok, err := dw.Write([]string{"k"}); ok || !errors.Is(err, ErrWriterClosed) {
+		t.Fatalf("Write() = %v, %v; want false, ErrWriterClosed", ok, err)
+	}
+	if _, dup := dw.seen["k"]; dup {
+		t.Fatalf("failed write marked key as seen")
+	}
+}
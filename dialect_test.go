@@ -0,0 +1,97 @@
+package swiftcsv
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestDialectRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	records := [][]string{
+		{"id", "name", "note"},
+		{"1", "plain", ""},
+		{"2", "with,comma", "he said \"hi\""},
+		{"3", "semi;colon", "multi\nline"},
+		{"4", "tab\there", "apostrophe's"},
+	}
+
+	tests := []struct {
+		name    string
+		dialect Dialect
+		prefix  string
+	}{
+		{name: "excel", dialect: DialectExcel, prefix: "id,name,note\r\n"},
+		{name: "unix", dialect: DialectUnix, prefix: "\"id\",\"name\",\"note\"\n"},
+		{name: "rfc4180", dialect: DialectRFC4180, prefix: "id,name,note\r\n"},
+		{name: "semicolon", dialect: Dialect{Comma: ';'}, prefix: "id;name;note\n"},
+		{name: "singleQuote", dialect: Dialect{Comma: '\t', Quote: '\''}, prefix: "id\tname\tnote\n"},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+			w := NewWriterDialect(&buf, tc.dialect)
+			if err := w.WriteAll(records); err != nil {
+				t.Fatalf("WriteAll() error = %v", err)
+			}
+			if err := w.Flush(); err != nil {
+				t.Fatalf("Flush() error = %v", err)
+			}
+			if !bytes.HasPrefix(buf.Bytes(), []byte(tc.prefix)) {
+				t.Fatalf("output prefix = %q, want %q", buf.String(), tc.prefix)
+			}
+
+			r := NewReaderDialect(bytes.NewReader(buf.Bytes()), tc.dialect)
+			got, err := r.ReadAll()
+			if err != nil {
+				t.Fatalf("ReadAll() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, records) {
+				t.Fatalf("round trip mismatch:\n got: %#v\nwant: %#v", got, records)
+			}
+		})
+	}
+}
+
+func TestDialectZeroValueDefaults(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	w := NewWriterDialect(&buf, Dialect{})
+	if w.Comma != ',' || w.Quote != '"' {
+		t.Fatalf("writer defaults = %q/%q, want ','/'\"'", w.Comma, w.Quote)
+	}
+
+	r := NewReaderDialect(&buf, Dialect{})
+	if r.Comma != ',' || r.Quote != '"' {
+		t.Fatalf("reader defaults = %q/%q, want ','/'\"'", r.Comma, r.Quote)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	records := [][]string{
+		{"id", "text"},
+		{"1", `embedded "quotes"`},
+		{"2", "line\nbreak", "crlf\r\ninside"},
+		{"3", "delims,;\t|"},
+		{"", ""},
+		{`"`, `""`, "'"},
+	}
+
+	for _, d := range []Dialect{DialectExcel, DialectUnix, DialectRFC4180, {Comma: '|', Quote: '\''}, {Comma: '\t'}} {
+		got, err := RoundTrip(records, d)
+		if err != nil {
+			t.Fatalf("RoundTrip(%+v) error = %v", d, err)
+		}
+		if !reflect.DeepEqual(got, records) {
+			t.Fatalf("RoundTrip(%+v) mismatch:\n got: %#v\nwant: %#v", d, got, records)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package swiftcsv
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		a, b string
+		want []DiffEntry
+	}{
+		{
+			name: "identical",
+			a:    "id,name\n1,\"multi\nline\"\n2,b\n",
+			b:    "id,name\r\n1,\"multi\nline\"\r\n\"2\",b",
+		},
+		{
+			name: "field differs",
+			a:    "id,name\n1,a\n2,b\n3,c\n",
+			b:    "id,name\n1,a\n2,B\n3,c\n",
+			want: []DiffEntry{{LineA: 3, LineB: 3, A: []string{"2", "b"}, B: []string{"2", "B"}}},
+		},
+		{
+			name: "width differs",
+			a:    "1,a\n2,b\n",
+			b:    "1,a,x\n2,b\n",
+			want: []DiffEntry{{LineA: 1, LineB: 1, A: []string{"1", "a"}, B: []string{"1", "a", "x"}}},
+		},
+		{
+			name: "a longer",
+			a:    "1,a\n2,b\n3,c\n",
+			b:    "1,a\n",
+			want: []DiffEntry{
+				{LineA: 2, A: []string{"2", "b"}},
+				{LineA: 3, A: []string{"3", "c"}},
+			},
+		},
+		{
+			name: "b longer",
+			a:    "",
+			b:    "1,a\n",
+			want: []DiffEntry{{LineB: 1, B: []string{"1", "a"}}},
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := Diff(NewReader(strings.NewReader(tc.a)), NewReader(strings.NewReader(tc.b)))
+			if err != nil {
+				t.Fatalf("Diff() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("Diff() = %#v, want %#v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDiffParseError(t *testing.T) {
+	t.Parallel()
+
+	a := NewReader(strings.NewReader("1,a\n2,b\n"))
+	b := NewReader(strings.NewReader("1,x\n2,\"b\n"))
+	got, err := Diff(a, b)
+	var perr *ParseError
+	if !errors.As(err, &perr) {
+		t.Fatalf("Diff() error = %v, want *ParseError", err)
+	}
+	if len(got) != 1 || got[0].LineA != 1 {
+		t.Fatalf("Diff() entries = %#v, want the line 1 difference", got)
+	}
+}
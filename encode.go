@@ -0,0 +1,135 @@
+package swiftcsv
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var errEncodeSource = errors.New("swiftcsv: WriteStructs requires a slice of structs or struct pointers")
+
+// structColumn is a struct field written as one CSV column.
+type structColumn struct {
+	name  string
+	index []int
+}
+
+// WriteStructs writes v, a slice of structs or struct pointers, as a header row followed by
+// one record per element. Column names come from the `csv` tag, or the field name when the tag
+// is absent. Fields tagged `csv:"-"` and unexported fields are skipped, and the fields of
+// embedded structs are flattened into the parent in declaration order. Strings, signed and
+// unsigned integers, floats, and bools are supported; nil pointer elements and nil embedded
+// pointers produce empty fields. An empty slice writes nothing.
+func (w *Writer) WriteStructs(v any) error {
+	if w == nil {
+		return errNilWriter
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return errEncodeSource
+	}
+	elem := rv.Type().Elem()
+	if elem.Kind() == reflect.Pointer {
+		elem = elem.Elem()
+	}
+	if elem.Kind() != reflect.Struct {
+		return errEncodeSource
+	}
+	if rv.Len() == 0 {
+		return nil
+	}
+
+	columns := structColumns(elem, nil)
+	record := make([]string, len(columns))
+	for i, c := range columns {
+		// Reject unsupported types before anything is written.
+		if _, err := formatField(reflect.Zero(elem.FieldByIndex(c.index).Type)); err != nil {
+			return fmt.Errorf("swiftcsv: column %q: %w", c.name, err)
+		}
+		record[i] = c.name
+	}
+	if err := w.Write(record); err != nil {
+		return err
+	}
+
+	for i := 0; i < rv.Len(); i++ {
+		item := rv.Index(i)
+		if item.Kind() == reflect.Pointer {
+			if item.IsNil() {
+				clear(record)
+				if err := w.Write(record); err != nil {
+					return err
+				}
+				continue
+			}
+			item = item.Elem()
+		}
+		for j, c := range columns {
+			field, err := item.FieldByIndexErr(c.index)
+			if err != nil {
+				// A nil embedded pointer leaves its promoted fields empty.
+				record[j] = ""
+				continue
+			}
+			if record[j], err = formatField(field); err != nil {
+				return fmt.Errorf("swiftcsv: column %q: %w", c.name, err)
+			}
+		}
+		if err := w.Write(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// structColumns lists the columns of typ, flattening embedded structs. prefix is the index
+// path of typ within the outermost struct.
+func structColumns(typ reflect.Type, prefix []int) []structColumn {
+	var columns []structColumn
+	for i := 0; i < typ.NumField(); i++ {
+		sf := typ.Field(i)
+		tag := sf.Tag.Get("csv")
+		if tag == "-" {
+			continue
+		}
+		index := append(append([]int(nil), prefix...), i)
+
+		ft := sf.Type
+		if ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		if sf.Anonymous && tag == "" && ft.Kind() == reflect.Struct {
+			columns = append(columns, structColumns(ft, index)...)
+			continue
+		}
+		if !sf.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = sf.Name
+		}
+		columns = append(columns, structColumn{name: name, index: index})
+	}
+	return columns
+}
+
+// formatField renders v in its canonical text form, the inverse of setField.
+func formatField(v reflect.Value) (string, error) {
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits()), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	default:
+		return "", fmt.Errorf("swiftcsv: unsupported field type %s", v.Type())
+	}
+}
@@ -0,0 +1,101 @@
+package swiftcsv
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+type encodeAudit struct {
+	Created string `csv:"created_at"`
+	Version uint8
+}
+
+type encodeItem struct {
+	ID     int     `csv:"id"`
+	Name   string  `csv:"name,omitempty"`
+	Price  float64 `csv:"price"`
+	Secret string  `csv:"-"`
+	note   string
+	encodeAudit
+	Active bool `csv:"active"`
+}
+
+func TestWriterWriteStructs(t *testing.T) {
+	t.Parallel()
+
+	items := []encodeItem{
+		{ID: 1, Name: "Widget", Price: 12.5, Secret: "x", note: "y", encodeAudit: encodeAudit{"2024-01-02", 3}, Active: true},
+		{ID: 2, Name: "Gadget, large", Price: 0.1},
+	}
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	if err := w.WriteStructs(items); err != nil {
+		t.Fatalf("WriteStructs() error = %v", err)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	want := "id,name,price,created_at,Version,active\n" +
+		"1,Widget,12.5,2024-01-02,3,true\n" +
+		"2,\"Gadget, large\",0.1,,0,false\n"
+	if got := buf.String(); got != want {
+		t.Fatalf("WriteStructs() wrote %q, want %q", got, want)
+	}
+}
+
+func TestWriterWriteStructsPointers(t *testing.T) {
+	t.Parallel()
+
+	type row struct {
+		*encodeAudit
+		Name string
+	}
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	if err := w.WriteStructs([]*row{{&encodeAudit{"today", 1}, "a"}, nil, {nil, "b"}}); err != nil {
+		t.Fatalf("WriteStructs() error = %v", err)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	want := "created_at,Version,Name\ntoday,1,a\n,,\n,,b\n"
+	if got := buf.String(); got != want {
+		t.Fatalf("WriteStructs() wrote %q, want %q", got, want)
+	}
+}
+
+func TestWriterWriteStructsEdgeCases(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	if err := w.WriteStructs([]encodeItem{}); err != nil {
+		t.Fatalf("WriteStructs(empty) error = %v", err)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Fatalf("WriteStructs(empty) wrote %q, want nothing", buf.String())
+	}
+
+	for _, v := range []any{nil, encodeItem{}, []string{"a"}} {
+		if err := w.WriteStructs(v); !errors.Is(err, errEncodeSource) {
+			t.Fatalf("WriteStructs(%T) error = %v, want %v", v, err, errEncodeSource)
+		}
+	}
+
+	type bad struct{ Tags []string }
+	err := w.WriteStructs([]bad{{Tags: []string{"x"}}})
+	if err == nil || !strings.Contains(err.Error(), "unsupported field type") {
+		t.Fatalf("WriteStructs(unsupported) error = %v, want unsupported field type", err)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Fatalf("WriteStructs(unsupported) wrote %q, want nothing", buf.String())
+	}
+}
@@ -0,0 +1,54 @@
+package swiftcsv
+
+// Field returns record[i], or the empty string when i is outside the bounds of record.
+// It allows optional trailing columns of ragged data to be read without length checks.
+func Field(record []string, i int) string {
+	if i < 0 || i >= len(record) {
+		return ""
+	}
+	return record[i]
+}
+
+// RecordEqual reports whether a and b hold the same fields in the same order. A nil record
+// equals an empty one.
+func RecordEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// FNV-1a 64-bit parameters used by RecordHash.
+const (
+	fnvOffset64 = 14695981039346656037
+	fnvPrime64  = 1099511628211
+)
+
+// RecordHash returns a 64-bit FNV-1a hash of record. Every field is followed by a separator
+// and by its length, so records that differ only in how the same bytes are split into fields
+// hash differently. The result depends only on the field contents and is stable across runs
+// and platforms, so it may be persisted; as with any 64-bit hash, distinct records can collide.
+func RecordHash(record []string) uint64 {
+	h := uint64(fnvOffset64)
+	for _, field := range record {
+		for i := 0; i < len(field); i++ {
+			h ^= uint64(field[i])
+			h *= fnvPrime64
+		}
+		h ^= 0xff
+		h *= fnvPrime64
+		for n := uint64(len(field)); ; n >>= 8 {
+			h ^= n & 0xff
+			h *= fnvPrime64
+			if n < 0x100 {
+				break
+			}
+		}
+	}
+	return h
+}
@@ -0,0 +1,102 @@
+package swiftcsv
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestField(t *testing.T) {
+	t.Parallel()
+
+	record := []string{"id", "name", ""}
+
+	tests := []struct {
+		name   string
+		record []string
+		index  int
+		want   string
+	}{
+		{name: "first", record: record, index: 0, want: "id"},
+		{name: "last", record: record, index: 1, want: "name"},
+		{name: "emptyField", record: record, index: 2, want: ""},
+		{name: "pastEnd", record: record, index: 3, want: ""},
+		{name: "negative", record: record, index: -1, want: ""},
+		{name: "nilRecord", record: nil, index: 0, want: ""},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := Field(tc.record, tc.index); got != tc.want {
+				t.Fatalf("Field(%d) = %q, want %q", tc.index, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRecordEqual(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		a, b []string
+		want bool
+	}{
+		{name: "equal", a: []string{"a", "b"}, b: []string{"a", "b"}, want: true},
+		{name: "nilAndEmpty", a: nil, b: []string{}, want: true},
+		{name: "fieldDiffers", a: []string{"a", "b"}, b: []string{"a", "c"}, want: false},
+		{name: "lengthDiffers", a: []string{"a"}, b: []string{"a", ""}, want: false},
+		{name: "orderDiffers", a: []string{"a", "b"}, b: []string{"b", "a"}, want: false},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := RecordEqual(tc.a, tc.b); got != tc.want {
+				t.Fatalf("RecordEqual(%q, %q) = %v, want %v", tc.a, tc.b, got, tc.want)
+			}
+			if tc.want && RecordHash(tc.a) != RecordHash(tc.b) {
+				t.Fatalf("RecordHash() differs for equal records %q and %q", tc.a, tc.b)
+			}
+		})
+	}
+}
+
+func TestRecordHash(t *testing.T) {
+	t.Parallel()
+
+	// Fixed values guard against the hash changing between releases.
+	stable := map[uint64][]string{
+		0xcbf29ce484222325: nil,
+		0x4a58f34e03209f35: {"id", "name"},
+	}
+	for want, record := range stable {
+		if got := RecordHash(record); got != want {
+			t.Fatalf("RecordHash(%q) = %#x, want %#x", record, got, want)
+		}
+	}
+
+	distinct := [][]string{
+		{"ab", "c"},
+		{"a", "bc"},
+		{"abc"},
+		{"abc", ""},
+		{"", "abc"},
+		{"a,b"},
+		{"a", "b"},
+		{strings.Repeat("x", 300)},
+		{strings.Repeat("x", 44)},
+	}
+	seen := make(map[uint64][]string)
+	for _, record := range distinct {
+		h := RecordHash(record)
+		if prev, dup := seen[h]; dup {
+			t.Fatalf("RecordHash(%q) collides with %q", record, prev)
+		}
+		seen[h] = record
+	}
+}
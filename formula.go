@@ -0,0 +1,45 @@
+package swiftcsv
+
+import "io"
+
+// Finding describes a field that a spreadsheet application would evaluate as a formula.
+type Finding struct {
+	// Line is the line on which the containing record starts.
+	Line int
+	// Column is the 1-based index of the field within its record.
+	Column int
+	// Value is the complete field value.
+	Value string
+}
+
+// ScanForFormulas reads every remaining record and reports each field starting with '=', '+',
+// '-', or '@', in input order, after FieldTransform when one is set. Records are not checked
+// against FieldsPerRecord, since the scan is about content rather than shape. On a parse error
+// the findings collected so far are returned together with the error.
+func (r *Reader) ScanForFormulas() ([]Finding, error) {
+	if r == nil || r.src == nil {
+		return nil, nil
+	}
+	var findings []Finding
+	for {
+		if err := r.readRecord(); err != nil {
+			if err == io.EOF {
+				return findings, nil
+			}
+			return findings, err
+		}
+		for i := 0; i < len(r.fieldBounds)/2; i++ {
+			field := r.dataBuf[r.fieldBounds[2*i]:r.fieldBounds[2*i+1]]
+			if r.FieldTransform != nil {
+				field = []byte(r.FieldTransform(i, string(field)))
+			}
+			if len(field) == 0 {
+				continue
+			}
+			switch field[0] {
+			case '=', '+', '-', '@':
+				findings = append(findings, Finding{Line: r.recordLine, Column: i + 1, Value: string(field)})
+			}
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package swiftcsv
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReaderScanForFormulas(t *testing.T) {
+	t.Parallel()
+
+	const input = "name,note\n" +
+		"alice,\"=HYPERLINK(\"\"http://x\"\")\"\n" +
+		"bob,safe a=b\n" +
+		"\"+1\",\"multi\nline\",@SUM(A1)\n" +
+		"-2,ok\n" +
+		",\n"
+
+	r := NewReader(strings.NewReader(input))
+	got, err := r.ScanForFormulas()
+	if err != nil {
+		t.Fatalf("ScanForFormulas() error = %v", err)
+	}
+	want := []Finding{
+		{Line: 2, Column: 2, Value: `=HYPERLINK("http://x")`},
+		{Line: 4, Column: 1, Value: "+1"},
+		{Line: 4, Column: 3, Value: "@SUM(A1)"},
+		{Line: 6, Column: 1, Value: "-2"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("ScanForFormulas() = %#v, want %#v", got, want)
+	}
+}
+
+func TestReaderScanForFormulasParseError(t *testing.T) {
+	t.Parallel()
+
+	r := NewReader(strings.NewReader("=1\n\"open\n"))
+	got, err := r.ScanForFormulas()
+	if !errors.Is(err, ErrUnterminatedQuote) {
+		t.Fatalf("ScanForFormulas() error = %v, want ErrUnterminatedQuote", err)
+	}
+	if want := []Finding{{Line: 1, Column: 1, Value: "=1"}}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("ScanForFormulas() = %#v, want %#v", got, want)
+	}
+}
@@ -0,0 +1,42 @@
+package swiftcsv
+
+import (
+	"errors"
+	"io"
+)
+
+// ErrKeyIndex is returned by GroupCount and MergeJoin when a key column index is negative or a
+// record has no field at that index.
+var ErrKeyIndex = errors.New("swiftcsv: key column index out of range")
+
+// GroupCount reads every remaining record and counts how many records share each distinct value
+// of the field at keyIndex, as returned by FieldTransform when one is set. Records are not
+// checked against FieldsPerRecord. A negative keyIndex fails with ErrKeyIndex before anything
+// is read, and a record too short to hold the key fails with a *ParseError wrapping
+// ErrKeyIndex; on that or any parse error the counts collected so far are returned together
+// with the error.
+func (r *Reader) GroupCount(keyIndex int) (map[string]int64, error) {
+	if keyIndex < 0 {
+		return nil, ErrKeyIndex
+	}
+	counts := make(map[string]int64)
+	if r == nil || r.src == nil {
+		return counts, nil
+	}
+	for {
+		if err := r.readRecord(); err != nil {
+			if err == io.EOF {
+				return counts, nil
+			}
+			return counts, err
+		}
+		if keyIndex >= len(r.fieldBounds)/2 {
+			return counts, &ParseError{Line: r.recordLine, Column: len(r.fieldBounds)/2 + 1, Err: ErrKeyIndex}
+		}
+		key := r.dataBuf[r.fieldBounds[2*keyIndex]:r.fieldBounds[2*keyIndex+1]]
+		if r.FieldTransform != nil {
+			key = []byte(r.FieldTransform(keyIndex, string(key)))
+		}
+		counts[string(key)]++
+	}
+}
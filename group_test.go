@@ -0,0 +1,63 @@
+package swiftcsv
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReaderGroupCount(t *testing.T) {
+	t.Parallel()
+
+	const input = "us,nyc\nde,berlin\nus,\"los\nangeles\"\nfr,paris\nde,munich\nus,nyc\n"
+
+	tests := []struct {
+		name     string
+		keyIndex int
+		want     map[string]int64
+	}{
+		{name: "country", keyIndex: 0, want: map[string]int64{"us": 3, "de": 2, "fr": 1}},
+		{name: "city", keyIndex: 1, want: map[string]int64{"nyc": 2, "berlin": 1, "los\nangeles": 1, "paris": 1, "munich": 1}},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := NewReader(strings.NewReader(input)).GroupCount(tc.keyIndex)
+			if err != nil {
+				t.Fatalf("GroupCount() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("GroupCount() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestReaderGroupCountMissingColumn(t *testing.T) {
+	t.Parallel()
+
+	got, err := NewReader(strings.NewReader("a,1,x\nb,2,y\na,3\na,4,z\n")).GroupCount(2)
+	var perr *ParseError
+	if !errors.As(err, &perr) || !errors.Is(err, ErrKeyIndex) {
+		t.Fatalf("GroupCount() error = %v, want *ParseError wrapping ErrKeyIndex", err)
+	}
+	if perr.Line != 3 || perr.Column != 3 {
+		t.Fatalf("GroupCount() error at line %d, column %d, want line 3, column 3", perr.Line, perr.Column)
+	}
+	if want := map[string]int64{"x": 1, "y": 1}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("GroupCount() partial counts = %v, want %v", got, want)
+	}
+
+	if _, err := NewReader(strings.NewReader("a\n")).GroupCount(-1); !errors.Is(err, ErrKeyIndex) {
+		t.Fatalf("GroupCount(-1) error = %v, want ErrKeyIndex", err)
+	}
+
+	got, err = NewReader(strings.NewReader("")).GroupCount(5)
+	if err != nil || len(got) != 0 {
+		t.Fatalf("GroupCount() on empty input = %v, %v, want empty map", got, err)
+	}
+}
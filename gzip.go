@@ -0,0 +1,35 @@
+package swiftcsv
+
+import (
+	"bufio"
+	"compress/gzip"
+	"errors"
+	"io"
+)
+
+// ErrNotGzip is returned by NewReaderGzip when the input does not start with the gzip magic bytes.
+var ErrNotGzip = errors.New("swiftcsv: input is not gzip compressed")
+
+// NewReaderGzip returns a Reader that transparently decompresses the gzip stream r. The stream is
+// buffered once and its magic bytes are checked up front, so non-gzip input fails with ErrNotGzip
+// instead of surfacing a decompression error on the first Read.
+func NewReaderGzip(r io.Reader) (*Reader, error) {
+	if r == nil {
+		return nil, errNilSource
+	}
+
+	br := bufio.NewReaderSize(r, defaultBufferSize)
+	magic, err := br.Peek(2)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	if len(magic) < 2 || magic[0] != 0x1f || magic[1] != 0x8b {
+		return nil, ErrNotGzip
+	}
+
+	zr, err := gzip.NewReader(br)
+	if err != nil {
+		return nil, err
+	}
+	return NewReader(zr), nil
+}
@@ -0,0 +1,50 @@
+package swiftcsv
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNewReaderGzip(t *testing.T) {
+	t.Parallel()
+
+	const input = "id,name\n1,\"alpha, beta\"\n2,gamma\n"
+	var compressed bytes.Buffer
+	zw := gzip.NewWriter(&compressed)
+	if _, err := zw.Write([]byte(strings.Repeat(input, 100))); err != nil {
+		t.Fatalf("gzip Write() error = %v", err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("gzip Close() error = %v", err)
+	}
+
+	r, err := NewReaderGzip(&compressed)
+	if err != nil {
+		t.Fatalf("NewReaderGzip() error = %v", err)
+	}
+	got, err := r.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+	if len(got) != 300 {
+		t.Fatalf("ReadAll() returned %d records, want 300", len(got))
+	}
+	want := [][]string{{"id", "name"}, {"1", "alpha, beta"}, {"2", "gamma"}}
+	if !reflect.DeepEqual(got[297:], want) {
+		t.Fatalf("ReadAll() tail = %#v, want %#v", got[297:], want)
+	}
+}
+
+func TestNewReaderGzipNotGzip(t *testing.T) {
+	t.Parallel()
+
+	for _, input := range []string{"", "a", "id,name\n1,alpha\n"} {
+		if _, err := NewReaderGzip(strings.NewReader(input)); !errors.Is(err, ErrNotGzip) {
+			t.Fatalf("NewReaderGzip(%q) error = %v, want ErrNotGzip", input, err)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package swiftcsv
+
+import (
+	"io"
+	"strconv"
+)
+
+// ReadHeader consumes the next record and installs it as the header used by ReadMap, returning
+// the header row after HeaderTransform has been applied. A header installed through SetHeader
+// takes precedence: the row is still consumed and returned, but the explicit header remains in effect.
+func (r *Reader) ReadHeader() ([]string, error) {
+	if r == nil {
+		return nil, io.EOF
+	}
+	record, err := r.Read()
+	if err != nil {
+		return nil, err
+	}
+	row := r.transformHeader(record)
+	if !r.headerSet {
+		r.installHeader(row)
+	}
+	return row, nil
+}
+
+// SetHeader installs names as the header without consuming a row, which allows map decoding
+// of files that carry no header line. The names are copied, passed through HeaderTransform, and
+// override any header obtained from ReadHeader, before or after this call.
+func (r *Reader) SetHeader(names []string) {
+	if r == nil {
+		return
+	}
+	r.installHeader(r.transformHeader(names))
+	r.headerSet = true
+}
+
+// ColumnIndex returns the position of name in the header currently in effect, or -1 when there is
+// no such column. Names are matched after HeaderTransform; the first duplicate wins.
+func (r *Reader) ColumnIndex(name string) int {
+	if r == nil {
+		return -1
+	}
+	if i, ok := r.headerIndex[name]; ok {
+		return i
+	}
+	return -1
+}
+
+// transformHeader returns a copy of names with HeaderTransform applied to each entry.
+func (r *Reader) transformHeader(names []string) []string {
+	out := cloneRecord(names)
+	if r.HeaderTransform != nil {
+		for i, name := range out {
+			out[i] = r.HeaderTransform(name)
+		}
+	}
+	return out
+}
+
+// installHeader makes names the active header and indexes it for ColumnIndex.
+func (r *Reader) installHeader(names []string) {
+	r.header = names
+	r.headerIndex = make(map[string]int, len(names))
+	for i, name := range names {
+		if _, dup := r.headerIndex[name]; !dup {
+			r.headerIndex[name] = i
+		}
+	}
+}
+
+// Header returns the header currently in effect, or nil when none has been read or set.
+func (r *Reader) Header() []string {
+	if r == nil {
+		return nil
+	}
+	return r.header
+}
+
+// ReadMap reads the next record and returns it keyed by header name. When no header is in
+// effect the next record is consumed as the header first. Fields beyond the header are
+// ignored and header names without a corresponding field map to the empty string.
+func (r *Reader) ReadMap() (map[string]string, error) {
+	if r == nil {
+		return nil, io.EOF
+	}
+	if r.header == nil {
+		if _, err := r.ReadHeader(); err != nil {
+			return nil, err
+		}
+	}
+
+	record, err := r.Read()
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[string]string, len(r.header))
+	for i, name := range r.header {
+		if i < len(record) {
+			out[name] = record[i]
+		} else {
+			out[name] = ""
+		}
+	}
+	return out, nil
+}
+
+// cloneRecord returns a copy of record whose strings do not alias reader-owned storage.
+func cloneRecord(record []string) []string {
+	out := make([]string, len(record))
+	for i, s := range record {
+		out[i] = string([]byte(s))
+	}
+	return out
+}
+
+// GuessHasHeader inspects the first two records and reports whether the first one is likely a
+// header: it contains no numeric fields while the second record has a numeric field in a column
+// where the first does not. Both records are pushed back, so subsequent reads still return them;
+// ReadLine does not see pushed-back records. Inputs with fewer than two records report false.
+// The guess is based on the next two records, so call it before reading any.
+func (r *Reader) GuessHasHeader() (bool, error) {
+	if r == nil || r.src == nil {
+		return false, io.EOF
+	}
+	if err := r.nextRecord(); err != nil {
+		if err == io.EOF {
+			return false, nil
+		}
+		return false, err
+	}
+	first := r.numericProfile()
+	r.pushBack()
+
+	if err := r.nextRecord(); err != nil {
+		if err == io.EOF {
+			return false, nil
+		}
+		return false, err
+	}
+	second := r.numericProfile()
+	r.pushBack()
+
+	header := false
+	for i, numeric := range first {
+		if numeric {
+			return false, nil
+		}
+		if i < len(second) && second[i] {
+			header = true
+		}
+	}
+	return header, nil
+}
+
+// numericProfile reports, per field of the record in dataBuf, whether it parses as a number.
+func (r *Reader) numericProfile() []bool {
+	profile := make([]bool, len(r.fieldBounds)/2)
+	for i := range profile {
+		field := r.dataBuf[r.fieldBounds[2*i]:r.fieldBounds[2*i+1]]
+		_, err := strconv.ParseFloat(string(field), 64)
+		profile[i] = err == nil
+	}
+	return profile
+}
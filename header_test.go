@@ -0,0 +1,291 @@
+package swiftcsv
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReaderReadMap(t *testing.T) {
+	t.Parallel()
+
+	r := NewReader(strings.NewReader("name,qty\nbolt,10\nnut,\n"))
+	r.ReuseRecord = true
+
+	want := []map[string]string{
+		{"name": "bolt", "qty": "10"},
+		{"name": "nut", "qty": ""},
+	}
+	for i, w := range want {
+		got, err := r.ReadMap()
+		if err != nil {
+			t.Fatalf("ReadMap() #%d error = %v", i+1, err)
+		}
+		if !reflect.DeepEqual(got, w) {
+			t.Fatalf("ReadMap() #%d = %#v, want %#v", i+1, got, w)
+		}
+	}
+	if !reflect.DeepEqual(r.Header(), []string{"name", "qty"}) {
+		t.Fatalf("Header() = %#v, want header row", r.Header())
+	}
+	if _, err := r.ReadMap(); !errors.Is(err, io.EOF) {
+		t.Fatalf("ReadMap() error = %v, want io.EOF", err)
+	}
+}
+
+func TestReaderSetHeader(t *testing.T) {
+	t.Parallel()
+
+	t.Run("headerlessData", func(t *testing.T) {
+		t.Parallel()
+
+		names := []string{"id", "city"}
+		r := NewReader(strings.NewReader("1,Oslo\n2,Lima\n"))
+		r.SetHeader(names)
+		names[0] = "mutated"
+
+		want := []map[string]string{
+			{"id": "1", "city": "Oslo"},
+			{"id": "2", "city": "Lima"},
+		}
+		for i, w := range want {
+			got, err := r.ReadMap()
+			if err != nil {
+				t.Fatalf("ReadMap() #%d error = %v", i+1, err)
+			}
+			if !reflect.DeepEqual(got, w) {
+				t.Fatalf("ReadMap() #%d = %#v, want %#v", i+1, got, w)
+			}
+		}
+	})
+
+	t.Run("explicitWinsOverReadHeader", func(t *testing.T) {
+		t.Parallel()
+
+		r := NewReader(strings.NewReader("ID,CITY\n1,Oslo\n"))
+		r.SetHeader([]string{"id", "city"})
+
+		row, err := r.ReadHeader()
+		if err != nil {
+			t.Fatalf("ReadHeader() error = %v", err)
+		}
+		if !reflect.DeepEqual(row, []string{"ID", "CITY"}) {
+			t.Fatalf("ReadHeader() = %#v, want consumed row", row)
+		}
+		got, err := r.ReadMap()
+		if err != nil {
+			t.Fatalf("ReadMap() error = %v", err)
+		}
+		if want := map[string]string{"id": "1", "city": "Oslo"}; !reflect.DeepEqual(got, want) {
+			t.Fatalf("ReadMap() = %#v, want %#v", got, want)
+		}
+	})
+
+	t.Run("setAfterReadHeader", func(t *testing.T) {
+		t.Parallel()
+
+		r := NewReader(strings.NewReader("ID,CITY\n1,Oslo\n"))
+		if _, err := r.ReadHeader(); err != nil {
+			t.Fatalf("ReadHeader() error = %v", err)
+		}
+		r.SetHeader([]string{"id", "city"})
+		if !reflect.DeepEqual(r.Header(), []string{"id", "city"}) {
+			t.Fatalf("Header() = %#v, want explicit header", r.Header())
+		}
+	})
+}
+
+func TestReaderHeaderTransform(t *testing.T) {
+	t.Parallel()
+
+	normalize := func(name string) string {
+		return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(name)), " ", "_")
+	}
+
+	r := NewReader(strings.NewReader("First Name, Last Name ,AGE\nAda,Lovelace,36\n"))
+	r.HeaderTransform = normalize
+
+	header, err := r.ReadHeader()
+	if err != nil {
+		t.Fatalf("ReadHeader() error = %v", err)
+	}
+	if want := []string{"first_name", "last_name", "age"}; !reflect.DeepEqual(header, want) {
+		t.Fatalf("ReadHeader() = %#v, want %#v", header, want)
+	}
+	if got := r.ColumnIndex("first_name"); got != 0 {
+		t.Fatalf("ColumnIndex(first_name) = %d, want 0", got)
+	}
+	if got := r.ColumnIndex("age"); got != 2 {
+		t.Fatalf("ColumnIndex(age) = %d, want 2", got)
+	}
+	if got := r.ColumnIndex("First Name"); got != -1 {
+		t.Fatalf("ColumnIndex(First Name) = %d, want -1", got)
+	}
+
+	row, err := r.ReadMap()
+	if err != nil {
+		t.Fatalf("ReadMap() error = %v", err)
+	}
+	if want := map[string]string{"first_name": "Ada", "last_name": "Lovelace", "age": "36"}; !reflect.DeepEqual(row, want) {
+		t.Fatalf("ReadMap() = %#v, want %#v", row, want)
+	}
+}
+
+func TestReaderHeaderTransformSetHeader(t *testing.T) {
+	t.Parallel()
+
+	r := NewReader(strings.NewReader("x\n"))
+	r.HeaderTransform = strings.ToUpper
+	r.SetHeader([]string{"id", "id"})
+
+	if want := []string{"ID", "ID"}; !reflect.DeepEqual(r.Header(), want) {
+		t.Fatalf("Header() = %#v, want %#v", r.Header(), want)
+	}
+	if got := r.ColumnIndex("ID"); got != 0 {
+		t.Fatalf("ColumnIndex(ID) = %d, want first duplicate 0", got)
+	}
+	if got := NewReader(strings.NewReader("")).ColumnIndex("ID"); got != -1 {
+		t.Fatalf("ColumnIndex() without header = %d, want -1", got)
+	}
+}
+
+func TestReaderGuessHasHeader(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input string
+		want  bool
+	}{
+		{name: "headered", input: "id,name,price\n1,apple,0.5\n2,pear,0.75\n", want: true},
+		{name: "headeredNoTrailingNewline", input: "id,price\n1,2.5", want: true},
+		{name: "headerless", input: "1,apple,0.5\n2,pear,0.75\n", want: false},
+		{name: "allText", input: "apple,red\npear,green\n", want: false},
+		{name: "singleRecord", input: "id,name\n", want: false},
+		{name: "empty", input: "", want: false},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			want, err := NewReader(strings.NewReader(tc.input)).ReadAll()
+			if err != nil {
+				t.Fatalf("ReadAll() error = %v", err)
+			}
+
+			r := NewReader(strings.NewReader(tc.input))
+			got, err := r.GuessHasHeader()
+			if err != nil {
+				t.Fatalf("GuessHasHeader() error = %v", err)
+			}
+			if got != tc.want {
+				t.Fatalf("GuessHasHeader() = %v, want %v", got, tc.want)
+			}
+
+			records, err := r.ReadAll()
+			if err != nil {
+				t.Fatalf("ReadAll() after GuessHasHeader error = %v", err)
+			}
+			if !reflect.DeepEqual(records, want) {
+				t.Fatalf("ReadAll() after GuessHasHeader = %#v, want %#v", records, want)
+			}
+		})
+	}
+}
+
+func TestReaderGuessHasHeaderReadMap(t *testing.T) {
+	t.Parallel()
+
+	r := NewReader(strings.NewReader("id,qty\n7,3\n"))
+	if ok, err := r.GuessHasHeader(); err != nil || !ok {
+		t.Fatalf("GuessHasHeader() = %v, %v; want true, nil", ok, err)
+	}
+	row, err := r.ReadMap()
+	if err != nil {
+		t.Fatalf("ReadMap() error = %v", err)
+	}
+	if want := map[string]string{"id": "7", "qty": "3"}; !reflect.DeepEqual(row, want) {
+		t.Fatalf("ReadMap() = %#v, want %#v", row, want)
+	}
+	if _, err := r.ReadMap(); !errors.Is(err, io.EOF) {
+		t.Fatalf("ReadMap() error = %v, want io.EOF", err)
+	}
+}
+
+func TestReaderHeaderDefinesWidth(t *testing.T) {
+	t.Parallel()
+
+	t.Run("readHeader", func(t *testing.T) {
+		t.Parallel()
+
+		r := NewReader(strings.NewReader("id,name,qty\n1,apple,3\n2,pear\n3,plum,7\n"))
+		r.HeaderDefinesWidth = true
+		if _, err := r.ReadHeader(); err != nil {
+			t.Fatalf("ReadHeader() error = %v", err)
+		}
+		if _, err := r.Read(); err != nil {
+			t.Fatalf("Read() matching row error = %v", err)
+		}
+
+		_, err := r.Read()
+		var perr *ParseError
+		var ferr *FieldCountError
+		if !errors.As(err, &perr) || !errors.As(err, &ferr) {
+			t.Fatalf("Read() ragged row error = %v, want *ParseError with *FieldCountError", err)
+		}
+		if perr.Line != 3 || ferr.Expected != 3 || ferr.Got != 2 {
+			t.Fatalf("error = line %d, %+v; want line 3, expected 3, got 2", perr.Line, *ferr)
+		}
+		if _, err := r.Read(); err != nil {
+			t.Fatalf("Read() after ragged row error = %v", err)
+		}
+	})
+
+	t.Run("setHeader", func(t *testing.T) {
+		t.Parallel()
+
+		r := NewReader(strings.NewReader("1,apple\n"))
+		r.HeaderDefinesWidth = true
+		r.SetHeader([]string{"id", "name", "qty"})
+		if _, err := r.Read(); !errors.Is(err, ErrorFieldCount) {
+			t.Fatalf("Read() error = %v, want ErrorFieldCount against the explicit header", err)
+		}
+	})
+
+	t.Run("firstRecordWithoutHeader", func(t *testing.T) {
+		t.Parallel()
+
+		r := NewReader(strings.NewReader("a,b\n1,2\n3\n"))
+		r.HeaderDefinesWidth = true
+		for i := 0; i < 2; i++ {
+			if _, err := r.Read(); err != nil {
+				t.Fatalf("Read() record %d error = %v", i, err)
+			}
+		}
+		if _, err := r.Read(); !errors.Is(err, ErrorFieldCount) {
+			t.Fatalf("Read() error = %v, want ErrorFieldCount", err)
+		}
+	})
+
+	t.Run("firstRecordOverridesFieldsPerRecord", func(t *testing.T) {
+		t.Parallel()
+
+		r := NewReader(strings.NewReader("a,b,c\n1,2,3\n4,5\n"))
+		r.FieldsPerRecord = 2
+		r.HeaderDefinesWidth = true
+		for i := 0; i < 2; i++ {
+			if _, err := r.Read(); err != nil {
+				t.Fatalf("Read() record %d error = %v", i, err)
+			}
+		}
+		_, err := r.Read()
+		var ferr *FieldCountError
+		if !errors.As(err, &ferr) || ferr.Expected != 3 || ferr.Got != 2 {
+			t.Fatalf("Read() error = %v, want *FieldCountError expecting 3 fields", err)
+		}
+	})
+}
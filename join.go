@@ -0,0 +1,92 @@
+package swiftcsv
+
+import "io"
+
+// joinCursor is one side of MergeJoin, holding its current record.
+type joinCursor struct {
+	r      *Reader
+	keyIdx int
+	record []string
+	key    string
+	done   bool
+}
+
+// advance reads the next record and its key, setting done at the end of the input.
+func (c *joinCursor) advance() error {
+	record, line, err := c.r.diffRecord()
+	if err == io.EOF {
+		c.record, c.key, c.done = nil, "", true
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if c.keyIdx >= len(record) {
+		return &ParseError{Line: line, Column: len(record) + 1, Err: ErrKeyIndex}
+	}
+	c.record, c.key = record, record[c.keyIdx]
+	return nil
+}
+
+// MergeJoin performs a streaming inner join of left and right on the fields at leftKey and
+// rightKey, writing one record to out for every pair of rows with equal keys: the left fields
+// followed by the right fields. Keys that appear several times on both sides produce every
+// combination. Only the right rows sharing the current key are held in memory.
+//
+// Both inputs must already be sorted ascending by key in byte-wise string order, as produced
+// by sort.Strings. The order is not verified; rows out of order are silently left unmatched.
+// Records are not checked against FieldsPerRecord. A negative key index fails with ErrKeyIndex
+// before anything is read, and a record too short to hold its key fails with a *ParseError
+// wrapping ErrKeyIndex. The joined records are written but not flushed.
+func MergeJoin(left, right *Reader, leftKey, rightKey int, out *Writer) error {
+	if leftKey < 0 || rightKey < 0 {
+		return ErrKeyIndex
+	}
+	if out == nil {
+		return errNilWriter
+	}
+	l := &joinCursor{r: left, keyIdx: leftKey}
+	r := &joinCursor{r: right, keyIdx: rightKey}
+	if err := l.advance(); err != nil {
+		return err
+	}
+	if err := r.advance(); err != nil {
+		return err
+	}
+
+	var group [][]string
+	var joined []string
+	for !l.done && !r.done {
+		switch {
+		case l.key < r.key:
+			if err := l.advance(); err != nil {
+				return err
+			}
+		case l.key > r.key:
+			if err := r.advance(); err != nil {
+				return err
+			}
+		default:
+			key := r.key
+			group = group[:0]
+			for !r.done && r.key == key {
+				group = append(group, r.record)
+				if err := r.advance(); err != nil {
+					return err
+				}
+			}
+			for !l.done && l.key == key {
+				for _, rec := range group {
+					joined = append(append(joined[:0], l.record...), rec...)
+					if err := out.Write(joined); err != nil {
+						return err
+					}
+				}
+				if err := l.advance(); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
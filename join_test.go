@@ -0,0 +1,86 @@
+package swiftcsv
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestMergeJoin(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		left, right string
+		want        string
+	}{
+		{
+			name:  "matching",
+			left:  "1,ann\n2,bob\n3,cy\n",
+			right: "x,1\ny,2\nz,3\n",
+			want:  "1,ann,x,1\n2,bob,y,2\n3,cy,z,3\n",
+		},
+		{
+			name:  "non-matching",
+			left:  "1,ann\n3,cy\n5,eve\n",
+			right: "x,0\ny,2\nz,4\nw,6\n",
+		},
+		{
+			name:  "partial",
+			left:  "1,ann\n2,bob\n4,dee\n",
+			right: "y,2\nz,3\nw,4\nv,5\n",
+			want:  "2,bob,y,2\n4,dee,w,4\n",
+		},
+		{
+			name:  "multi-match",
+			left:  "1,a\n2,b1\n2,b2\n3,c\n",
+			right: "p,2\nq,2\nr,3\ns,3\n",
+			want:  "2,b1,p,2\n2,b1,q,2\n2,b2,p,2\n2,b2,q,2\n3,c,r,3\n3,c,s,3\n",
+		},
+		{
+			name:  "empty",
+			left:  "",
+			right: "x,1\n",
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+			w := NewWriter(&buf)
+			left := NewReader(strings.NewReader(tc.left))
+			right := NewReader(strings.NewReader(tc.right))
+			if err := MergeJoin(left, right, 0, 1, w); err != nil {
+				t.Fatalf("MergeJoin() error = %v", err)
+			}
+			if err := w.Flush(); err != nil {
+				t.Fatalf("Flush() error = %v", err)
+			}
+			if got := buf.String(); got != tc.want {
+				t.Fatalf("MergeJoin() wrote %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestMergeJoinKeyIndex(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	if err := MergeJoin(NewReader(strings.NewReader("1\n")), NewReader(strings.NewReader("1\n")), -1, 0, w); err != ErrKeyIndex {
+		t.Fatalf("MergeJoin(-1) error = %v, want %v", err, ErrKeyIndex)
+	}
+
+	left := NewReader(strings.NewReader("1,a\n2,b\n"))
+	right := NewReader(strings.NewReader("x,1\ny\n"))
+	err := MergeJoin(left, right, 0, 1, w)
+	var perr *ParseError
+	if !errors.As(err, &perr) || !errors.Is(err, ErrKeyIndex) || perr.Line != 2 || perr.Column != 2 {
+		t.Fatalf("MergeJoin() error = %v, want *ParseError at line 2, column 2 wrapping %v", err, ErrKeyIndex)
+	}
+}
@@ -0,0 +1,35 @@
+package swiftcsv
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// ArrayJSONWriter emits records as newline-delimited JSON arrays of strings, one record per
+// line, for consumers that want positional values without a header. Each record is written to
+// the destination immediately.
+type ArrayJSONWriter struct {
+	enc *json.Encoder
+}
+
+// NewArrayJSONWriter creates an ArrayJSONWriter that writes to w. HTML-sensitive characters
+// are written verbatim rather than as \u escapes.
+func NewArrayJSONWriter(w io.Writer) *ArrayJSONWriter {
+	if w == nil {
+		panic(errWriterNoTarget.Error())
+	}
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	return &ArrayJSONWriter{enc: enc}
+}
+
+// Write emits record as a JSON array followed by a newline. A nil record is written as [].
+func (a *ArrayJSONWriter) Write(record []string) error {
+	if a == nil {
+		return errNilWriter
+	}
+	if record == nil {
+		record = []string{}
+	}
+	return a.enc.Encode(record)
+}
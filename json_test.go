@@ -0,0 +1,49 @@
+package swiftcsv
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestArrayJSONWriter(t *testing.T) {
+	t.Parallel()
+
+	records := [][]string{
+		{"id", "note"},
+		{"1", `say "hi"`},
+		{"2", `C:\path`, "two\nlines\ttab"},
+		{"3", "<b>&amp;</b>", "ünï"},
+		nil,
+	}
+
+	var buf bytes.Buffer
+	aw := NewArrayJSONWriter(&buf)
+	for _, rec := range records {
+		if err := aw.Write(rec); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+
+	want := `["id","note"]` + "\n" +
+		`["1","say \"hi\""]` + "\n" +
+		`["2","C:\\path","two\nlines\ttab"]` + "\n" +
+		`["3","<b>&amp;</b>","ünï"]` + "\n" +
+		"[]\n"
+	if got := buf.String(); got != want {
+		t.Fatalf("unexpected output:\n got: %q\nwant: %q", got, want)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	for i, line := range lines[:len(lines)-1] {
+		var decoded []string
+		if err := json.Unmarshal([]byte(line), &decoded); err != nil {
+			t.Fatalf("line %d: Unmarshal() error = %v", i, err)
+		}
+		if !reflect.DeepEqual(decoded, records[i]) {
+			t.Fatalf("line %d decoded as %#v, want %#v", i, decoded, records[i])
+		}
+	}
+}
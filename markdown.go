@@ -0,0 +1,91 @@
+package swiftcsv
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// markdownEscaper escapes cell content that would otherwise break the table structure.
+var markdownEscaper = strings.NewReplacer("|", `\|`, "\r\n", "<br>", "\n", "<br>", "\r", "<br>")
+
+// MarkdownWriter renders records as a Markdown table. Rows are buffered until Flush so every
+// row can be padded to the widest one; short rows receive empty trailing cells. Pipe characters
+// in fields are escaped as \| and line breaks are written as <br>.
+type MarkdownWriter struct {
+	dst     io.Writer
+	header  []string
+	rows    [][]string
+	columns int
+}
+
+// NewMarkdownWriter creates a MarkdownWriter that emits a table to w on Flush.
+func NewMarkdownWriter(w io.Writer) *MarkdownWriter {
+	if w == nil {
+		panic(errWriterNoTarget.Error())
+	}
+	return &MarkdownWriter{dst: w}
+}
+
+// WriteHeader sets a copy of header as the table header, replacing any earlier one. When no
+// header is set, the first row written becomes the header.
+func (m *MarkdownWriter) WriteHeader(header []string) error {
+	if m == nil {
+		return errNilWriter
+	}
+	m.header = cloneRecord(header)
+	m.columns = max(m.columns, len(header))
+	return nil
+}
+
+// Write buffers a copy of record as a table row.
+func (m *MarkdownWriter) Write(record []string) error {
+	if m == nil {
+		return errNilWriter
+	}
+	m.rows = append(m.rows, cloneRecord(record))
+	m.columns = max(m.columns, len(record))
+	return nil
+}
+
+// Flush writes the header, the separator line, and every buffered row, then discards the
+// buffered table. Nothing is written when no header or row has been buffered.
+func (m *MarkdownWriter) Flush() error {
+	if m == nil {
+		return errNilWriter
+	}
+	header, rows := m.header, m.rows
+	if header == nil && len(rows) > 0 {
+		header, rows = rows[0], rows[1:]
+	}
+	if header == nil {
+		return nil
+	}
+
+	bw := bufio.NewWriterSize(m.dst, defaultBufferSize)
+	m.writeRow(bw, header)
+	for i := 0; i < m.columns; i++ {
+		bw.WriteString("| --- ")
+	}
+	bw.WriteString("|\n")
+	for _, row := range rows {
+		m.writeRow(bw, row)
+	}
+
+	m.header = nil
+	m.rows = m.rows[:0]
+	m.columns = 0
+	return bw.Flush()
+}
+
+// writeRow emits row as a table line padded with empty cells to the column count.
+func (m *MarkdownWriter) writeRow(bw *bufio.Writer, row []string) {
+	for i := 0; i < m.columns; i++ {
+		bw.WriteString("| ")
+		if i < len(row) {
+			markdownEscaper.WriteString(bw, row[i])
+		}
+		bw.WriteByte(' ')
+	}
+	bw.WriteString("|\n")
+}
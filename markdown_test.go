@@ -0,0 +1,65 @@
+package swiftcsv
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestMarkdownWriter(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	mw := NewMarkdownWriter(&buf)
+
+	header := []string{"id", "expr", "note"}
+	if err := mw.WriteHeader(header); err != nil {
+		t.Fatalf("WriteHeader() error = %v", err)
+	}
+	header[0] = "mutated"
+	for _, rec := range [][]string{
+		{"1", "a|b", "two\nlines"},
+		{"2"},
+		{"3", "", "x", "extra"},
+	} {
+		if err := mw.Write(rec); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+	if err := mw.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	want := "" +
+		"| id | expr | note |  |\n" +
+		"| --- | --- | --- | --- |\n" +
+		"| 1 | a\\|b | two<br>lines |  |\n" +
+		"| 2 |  |  |  |\n" +
+		"| 3 |  | x | extra |\n"
+	if got := buf.String(); got != want {
+		t.Fatalf("unexpected output:\n got: %q\nwant: %q", got, want)
+	}
+}
+
+func TestMarkdownWriterImplicitHeader(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	mw := NewMarkdownWriter(&buf)
+	if err := mw.Flush(); err != nil || buf.Len() != 0 {
+		t.Fatalf("Flush() on empty table = %q, %v; want no output", buf.String(), err)
+	}
+
+	for _, rec := range [][]string{{"name", "qty"}, {"pear", "2"}} {
+		if err := mw.Write(rec); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+	if err := mw.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	want := "| name | qty |\n| --- | --- |\n| pear | 2 |\n"
+	if got := buf.String(); got != want {
+		t.Fatalf("unexpected output:\n got: %q\nwant: %q", got, want)
+	}
+}
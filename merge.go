@@ -0,0 +1,86 @@
+package swiftcsv
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// MergedReader reads several CSV sources as one stream, tagging every record with the key of
+// the source it came from. Sources are read one after another to completion in sorted key
+// order.
+type MergedReader struct {
+	keys    []string
+	readers map[string]*Reader
+	next    int
+
+	// TagLast appends the source key as the last field of each record instead of prepending
+	// it as the first.
+	TagLast bool
+}
+
+// NewMergedReader creates a MergedReader over sources, wrapping each one in a Reader with the
+// NewReader defaults. It panics if any source is nil.
+func NewMergedReader(sources map[string]io.Reader) *MergedReader {
+	m := &MergedReader{
+		keys:    make([]string, 0, len(sources)),
+		readers: make(map[string]*Reader, len(sources)),
+	}
+	for key, src := range sources {
+		m.keys = append(m.keys, key)
+		m.readers[key] = NewReader(src)
+	}
+	slices.Sort(m.keys)
+	return m
+}
+
+// Source returns the Reader for the source with the given key, or nil if there is none. Use it
+// before the first Read to configure a source individually, for example to set its Comma or
+// to consume its header with ReadHeader.
+func (m *MergedReader) Source(key string) *Reader {
+	if m == nil {
+		return nil
+	}
+	return m.readers[key]
+}
+
+// Read returns the next record of the current source with the source key added as an extra
+// field, moving on to the next source when the current one is exhausted. The returned record is
+// never reused, even when a source has ReuseRecord set. io.EOF is returned once every
+// source is exhausted. Errors from a source are wrapped with its key and can be inspected with
+// errors.As.
+func (m *MergedReader) Read() ([]string, error) {
+	if m == nil {
+		return nil, io.EOF
+	}
+	for m.next < len(m.keys) {
+		key := m.keys[m.next]
+		r := m.readers[key]
+		fields, err := r.Read()
+		if err == io.EOF {
+			m.next++
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("swiftcsv: source %q: %w", key, err)
+		}
+		record := make([]string, 0, len(fields)+1)
+		if !m.TagLast {
+			record = append(record, key)
+		}
+		if r.ReuseRecord {
+			// The fields alias storage the source overwrites on its next Read.
+			for _, f := range fields {
+				record = append(record, strings.Clone(f))
+			}
+		} else {
+			record = append(record, fields...)
+		}
+		if m.TagLast {
+			record = append(record, key)
+		}
+		return record, nil
+	}
+	return nil, io.EOF
+}
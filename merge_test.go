@@ -0,0 +1,87 @@
+package swiftcsv
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMergedReader(t *testing.T) {
+	t.Parallel()
+
+	m := NewMergedReader(map[string]io.Reader{
+		"west.csv": strings.NewReader("id;name\n3;Cy\n"),
+		"east.csv": strings.NewReader("id,name\n1,Ann\n2,\"Bo, Jr\"\n"),
+	})
+	if _, err := m.Source("east.csv").ReadHeader(); err != nil {
+		t.Fatalf("ReadHeader() error = %v", err)
+	}
+	west := m.Source("west.csv")
+	west.Comma = ';'
+	west.ReuseRecord = true
+	if m.Source("missing.csv") != nil {
+		t.Fatalf("Source(missing) = non-nil, want nil")
+	}
+
+	var got [][]string
+	for {
+		record, err := m.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Read() error = %v", err)
+		}
+		got = append(got, record)
+	}
+	want := [][]string{
+		{"east.csv", "1", "Ann"},
+		{"east.csv", "2", "Bo, Jr"},
+		{"west.csv", "id", "name"},
+		{"west.csv", "3", "Cy"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Read() = %q, want %q", got, want)
+	}
+}
+
+func TestMergedReaderTagLast(t *testing.T) {
+	t.Parallel()
+
+	m := NewMergedReader(map[string]io.Reader{
+		"b": strings.NewReader("2\n"),
+		"a": strings.NewReader(""),
+		"c": strings.NewReader("3,x\n"),
+	})
+	m.TagLast = true
+	for _, want := range [][]string{{"2", "b"}, {"3", "x", "c"}} {
+		got, err := m.Read()
+		if err != nil {
+			t.Fatalf("Read() error = %v", err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("Read() = %q, want %q", got, want)
+		}
+	}
+	if _, err := m.Read(); err != io.EOF {
+		t.Fatalf("Read() error = %v, want io.EOF", err)
+	}
+}
+
+func TestMergedReaderError(t *testing.T) {
+	t.Parallel()
+
+	m := NewMergedReader(map[string]io.Reader{"bad": strings.NewReader("1,\"x\n")})
+	_, err := m.Read()
+	var perr *ParseError
+	if !errors.As(err, &perr) || !strings.Contains(err.Error(), `"bad"`) {
+		t.Fatalf("Read() error = %v, want *ParseError naming the source", err)
+	}
+
+	var nilMerged *MergedReader
+	if _, err := nilMerged.Read(); err != io.EOF {
+		t.Fatalf("nil Read() error = %v, want io.EOF", err)
+	}
+}
@@ -0,0 +1,102 @@
+package swiftcsv
+
+import (
+	"bytes"
+	"io"
+	"runtime"
+	"sync"
+)
+
+// ParseParallel parses data with up to workers concurrent Readers and returns the records in
+// input order, matching what ReadAll would produce for the whole slice. data is split only
+// after a newline that lies outside quoted fields, determined by quote parity, so embedded line
+// breaks are never cut; input relying on bare quotes inside unquoted fields may therefore end up
+// in a single chunk. The width of the first record is enforced across every chunk, and a
+// *ParseError reports its line relative to the start of data. A non-positive workers value uses
+// runtime.GOMAXPROCS(0).
+func ParseParallel(data []byte, workers int, comma byte) ([][]string, error) {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if comma == 0 {
+		comma = ','
+	}
+	if !validDelimiters(comma, '"') {
+		return nil, ErrInvalidDelimiter
+	}
+
+	first := NewReader(bytes.NewReader(data))
+	first.Comma = comma
+	head, err := first.Read()
+	if err != nil {
+		if err == io.EOF {
+			return nil, nil
+		}
+		return nil, err
+	}
+	width := len(head)
+
+	bounds, lines := splitRecords(data, workers)
+	results := make([][][]string, len(bounds)-1)
+	errs := make([]error, len(bounds)-1)
+
+	var wg sync.WaitGroup
+	for i := 0; i < len(bounds)-1; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			r := NewReader(bytes.NewReader(data[bounds[i]:bounds[i+1]]))
+			r.Comma = comma
+			r.FieldsPerRecord = width
+			r.line += lines[i]
+			results[i], errs[i] = r.ReadAll()
+		}(i)
+	}
+	wg.Wait()
+
+	total := 0
+	for i, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+		total += len(results[i])
+	}
+	records := make([][]string, 0, total)
+	for _, chunk := range results {
+		records = append(records, chunk...)
+	}
+	return records, nil
+}
+
+// splitRecords divides data into at most n chunks that each end after an unquoted newline. It
+// returns the chunk boundaries, including 0 and len(data), and the number of line breaks that
+// precede each chunk, counted as the Reader counts them: CRLF once and a lone CR on its own,
+// whether quoted or not.
+func splitRecords(data []byte, n int) (bounds []int, lines []int) {
+	bounds = append(bounds, 0)
+	lines = append(lines, 0)
+	target := len(data) / n
+	if target == 0 {
+		target = len(data)
+	}
+
+	inQuotes := false
+	breaks := 0
+	for i, b := range data {
+		switch b {
+		case '"':
+			inQuotes = !inQuotes
+		case '\r':
+			if i+1 == len(data) || data[i+1] != '\n' {
+				breaks++
+			}
+		case '\n':
+			breaks++
+			if !inQuotes && i+1-bounds[len(bounds)-1] >= target && i+1 < len(data) && len(bounds) < n {
+				bounds = append(bounds, i+1)
+				lines = append(lines, breaks)
+			}
+		}
+	}
+	return append(bounds, len(data)), lines
+}
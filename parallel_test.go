@@ -0,0 +1,101 @@
+package swiftcsv
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseParallel(t *testing.T) {
+	t.Parallel()
+
+	var b strings.Builder
+	b.WriteString("id,note,qty\r\n")
+	for i := 0; i < 500; i++ {
+		switch i % 5 {
+		case 0:
+			fmt.Fprintf(&b, "%d,\"multi\nline\n\",%d\n", i, i*2)
+		case 1:
+			fmt.Fprintf(&b, "%d,\"quoted \"\"x\"\"\r\n\",%d\r\n", i, i*2)
+		case 2:
+			fmt.Fprintf(&b, "%d,,\n", i)
+		case 3:
+			fmt.Fprintf(&b, "%d,\"lone\rcr\",%d\r", i, i)
+		default:
+			fmt.Fprintf(&b, "%d,plain,%d\n", i, i)
+		}
+	}
+	data := []byte(b.String())
+
+	want, err := NewReader(bytes.NewReader(data)).ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+
+	for _, workers := range []int{0, 1, 2, 3, 8, 64, 10000} {
+		got, err := ParseParallel(data, workers, ',')
+		if err != nil {
+			t.Fatalf("ParseParallel(%d) error = %v", workers, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("ParseParallel(%d) returned %d records differing from sequential ReadAll (%d)", workers, len(got), len(want))
+		}
+	}
+
+	// A short record at the end must be reported on the same line as by the sequential reader.
+	bad := append(data[:len(data):len(data)], "1,2\n"...)
+	_, err = NewReader(bytes.NewReader(bad)).ReadAll()
+	var wantErr *ParseError
+	if !errors.As(err, &wantErr) {
+		t.Fatalf("ReadAll() error = %v, want *ParseError", err)
+	}
+	for _, workers := range []int{1, 2, 3, 8, 64} {
+		_, err := ParseParallel(bad, workers, ',')
+		var perr *ParseError
+		if !errors.As(err, &perr) || perr.Line != wantErr.Line {
+			t.Fatalf("ParseParallel(%d) error = %v, want line %d", workers, err, wantErr.Line)
+		}
+	}
+}
+
+func TestParseParallelEdgeCases(t *testing.T) {
+	t.Parallel()
+
+	if got, err := ParseParallel(nil, 4, ','); err != nil || got != nil {
+		t.Fatalf("ParseParallel(nil) = %v, %v; want nil, nil", got, err)
+	}
+
+	got, err := ParseParallel([]byte("a;b\nc;d"), 4, ';')
+	if err != nil {
+		t.Fatalf("ParseParallel() error = %v", err)
+	}
+	if want := [][]string{{"a", "b"}, {"c", "d"}}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("ParseParallel() = %#v, want %#v", got, want)
+	}
+
+	if _, err := ParseParallel([]byte("a\n"), 2, '\n'); !errors.Is(err, ErrInvalidDelimiter) {
+		t.Fatalf("ParseParallel() error = %v, want ErrInvalidDelimiter", err)
+	}
+}
+
+func TestParseParallelErrors(t *testing.T) {
+	t.Parallel()
+
+	data := []byte(strings.Repeat("a,b\n", 100) + "c,\"d\n")
+	_, err := ParseParallel(data, 4, ',')
+	var perr *ParseError
+	if !errors.As(err, &perr) || !errors.Is(err, ErrUnterminatedQuote) {
+		t.Fatalf("ParseParallel() error = %v, want *ParseError wrapping ErrUnterminatedQuote", err)
+	}
+	if perr.Line != 102 {
+		t.Fatalf("ParseError.Line = %d, want 102", perr.Line)
+	}
+
+	data = []byte(strings.Repeat("a,b\n", 100) + "c\n")
+	if _, err := ParseParallel(data, 4, ','); !errors.Is(err, ErrorFieldCount) {
+		t.Fatalf("ParseParallel() error = %v, want ErrorFieldCount", err)
+	}
+}
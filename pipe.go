@@ -0,0 +1,120 @@
+package swiftcsv
+
+import "io"
+
+// Pipe streams every record from src through transform into dst and returns the number of
+// records written. A transform that returns a nil slice drops the record; a nil transform
+// copies records unchanged. dst is flushed before Pipe returns, and the first read, transform,
+// write, or flush error is reported.
+func Pipe(src *Reader, dst *Writer, transform func([]string) ([]string, error)) (int64, error) {
+	if src == nil {
+		return 0, errNilSource
+	}
+	if dst == nil {
+		return 0, errNilWriter
+	}
+
+	var written int64
+	for {
+		record, err := src.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return written, flushOnError(dst, err)
+		}
+		if transform != nil {
+			record, err = transform(record)
+			if err != nil {
+				return written, flushOnError(dst, err)
+			}
+			if record == nil {
+				continue
+			}
+		}
+		if err := dst.Write(record); err != nil {
+			return written, err
+		}
+		written++
+	}
+	return written, dst.Flush()
+}
+
+// Pipeline chains record transformations applied in registration order. The zero value is an
+// empty pipeline that copies records unchanged.
+type Pipeline struct {
+	stages []func([]string) ([]string, error)
+}
+
+// Use appends stage to the pipeline and returns the pipeline for chaining. A stage that returns
+// a nil slice drops the record and later stages do not see it.
+func (p *Pipeline) Use(stage func([]string) ([]string, error)) *Pipeline {
+	p.stages = append(p.stages, stage)
+	return p
+}
+
+// Run streams every record from src through the stages into dst with the same semantics as
+// Pipe, returning the first error from reading, any stage, writing, or flushing.
+func (p *Pipeline) Run(src *Reader, dst *Writer) error {
+	_, err := Pipe(src, dst, p.apply)
+	return err
+}
+
+// apply runs record through every stage, stopping once a stage drops it.
+func (p *Pipeline) apply(record []string) ([]string, error) {
+	for _, stage := range p.stages {
+		var err error
+		if record, err = stage(record); err != nil || record == nil {
+			return nil, err
+		}
+	}
+	return record, nil
+}
+
+// flushOnError flushes dst so already written records reach the destination and returns err,
+// which takes precedence over any flush failure.
+func flushOnError(dst *Writer, err error) error {
+	_ = dst.Flush()
+	return err
+}
+
+// Transform copies CSV from src to dst with the columns at dropIndices removed, flushing dst
+// at the end. Records may differ in width, indexes beyond a record are ignored, and remaining
+// fields are re-quoted only where required.
+func Transform(src io.Reader, dst io.Writer, dropIndices []int) error {
+	if src == nil {
+		return errNilSource
+	}
+	if dst == nil {
+		return errWriterNoTarget
+	}
+
+	drop := make(map[int]bool, len(dropIndices))
+	for _, i := range dropIndices {
+		drop[i] = true
+	}
+
+	r := NewReader(src)
+	r.ReuseRecord = true
+	w := NewWriter(dst)
+	var out []string
+	for {
+		record, err := r.readRagged()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return flushOnError(w, err)
+		}
+		out = out[:0]
+		for i, field := range record {
+			if !drop[i] {
+				out = append(out, field)
+			}
+		}
+		if err := w.Write(out); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
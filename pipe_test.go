@@ -0,0 +1,241 @@
+package swiftcsv
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPipe(t *testing.T) {
+	t.Parallel()
+
+	const input = "id,name,qty\n1,apple,3\n2,pear,0\n3,plum,7\n"
+
+	tests := []struct {
+		name      string
+		transform func([]string) ([]string, error)
+		want      string
+		count     int64
+	}{
+		{
+			name:  "identity",
+			want:  input,
+			count: 4,
+		},
+		{
+			name: "filter",
+			transform: func(rec []string) ([]string, error) {
+				if rec[2] == "0" {
+					return nil, nil
+				}
+				return rec, nil
+			},
+			want:  "id,name,qty\n1,apple,3\n3,plum,7\n",
+			count: 3,
+		},
+		{
+			name: "map",
+			transform: func(rec []string) ([]string, error) {
+				return []string{rec[1], strings.ToUpper(rec[1]) + ", inc"}, nil
+			},
+			want:  "name,\"NAME, inc\"\napple,\"APPLE, inc\"\npear,\"PEAR, inc\"\nplum,\"PLUM, inc\"\n",
+			count: 4,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+			n, err := Pipe(NewReader(strings.NewReader(input)), NewWriter(&buf), tc.transform)
+			if err != nil {
+				t.Fatalf("Pipe() error = %v", err)
+			}
+			if n != tc.count {
+				t.Fatalf("Pipe() count = %d, want %d", n, tc.count)
+			}
+			if got := buf.String(); got != tc.want {
+				t.Fatalf("unexpected output:\n got: %q\nwant: %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestPipeErrors(t *testing.T) {
+	t.Parallel()
+
+	t.Run("transformError", func(t *testing.T) {
+		t.Parallel()
+
+		exp := errors.New("bad row")
+		var buf bytes.Buffer
+		n, err := Pipe(NewReader(strings.NewReader("a\nb\nc\n")), NewWriter(&buf), func(rec []string) ([]string, error) {
+			if rec[0] == "b" {
+				return nil, exp
+			}
+			return rec, nil
+		})
+		if !errors.Is(err, exp) {
+			t.Fatalf("Pipe() error = %v, want %v", err, exp)
+		}
+		if n != 1 {
+			t.Fatalf("Pipe() count = %d, want 1", n)
+		}
+		if got := buf.String(); got != "a\n" {
+			t.Fatalf("partial output = %q, want flushed %q", got, "a\n")
+		}
+	})
+
+	t.Run("readError", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		n, err := Pipe(NewReader(strings.NewReader("ok\n\"broken\n")), NewWriter(&buf), nil)
+		if !errors.Is(err, ErrUnterminatedQuote) {
+			t.Fatalf("Pipe() error = %v, want ErrUnterminatedQuote", err)
+		}
+		if n != 1 || buf.String() != "ok\n" {
+			t.Fatalf("Pipe() = %d, %q; want 1, %q", n, buf.String(), "ok\n")
+		}
+	})
+
+	t.Run("nilArguments", func(t *testing.T) {
+		t.Parallel()
+
+		if _, err := Pipe(nil, NewWriter(io.Discard), nil); !errors.Is(err, errNilSource) {
+			t.Fatalf("Pipe(nil src) error = %v, want %v", err, errNilSource)
+		}
+		if _, err := Pipe(NewReader(strings.NewReader("a\n")), nil, nil); !errors.Is(err, errNilWriter) {
+			t.Fatalf("Pipe(nil dst) error = %v, want %v", err, errNilWriter)
+		}
+	})
+
+	t.Run("writeError", func(t *testing.T) {
+		t.Parallel()
+
+		exp := errors.New("disk full")
+		_, err := Pipe(NewReader(strings.NewReader("a\n")), NewWriter(&flushFailWriter{fail: exp}), nil)
+		if !errors.Is(err, exp) {
+			t.Fatalf("Pipe() error = %v, want %v", err, exp)
+		}
+	})
+}
+
+func TestTransform(t *testing.T) {
+	t.Parallel()
+
+	const input = "id,name,note,qty\n1,\"a,b\",\"say \"\"hi\"\"\",3\n2,short\n3,x,\"multi\nline\",9\n"
+
+	tests := []struct {
+		name string
+		drop []int
+		want string
+	}{
+		{name: "middle", drop: []int{1}, want: "id,note,qty\n1,\"say \"\"hi\"\"\",3\n2\n3,\"multi\nline\",9\n"},
+		{name: "last", drop: []int{3}, want: "id,name,note\n1,\"a,b\",\"say \"\"hi\"\"\"\n2,short\n3,x,\"multi\nline\"\n"},
+		{name: "severalAndOutOfRange", drop: []int{2, 0, 7}, want: "name,qty\n\"a,b\",3\nshort\nx,9\n"},
+		{name: "none", drop: nil, want: input},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+			if err := Transform(strings.NewReader(input), &buf, tc.drop); err != nil {
+				t.Fatalf("Transform() error = %v", err)
+			}
+			if got := buf.String(); got != tc.want {
+				t.Fatalf("unexpected output:\n got: %q\nwant: %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestTransformReadError(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	err := Transform(strings.NewReader("a,b\nc,\"d\n"), &buf, []int{0})
+	if !errors.Is(err, ErrUnterminatedQuote) {
+		t.Fatalf("Transform() error = %v, want ErrUnterminatedQuote", err)
+	}
+	if got := buf.String(); got != "b\n" {
+		t.Fatalf("partial output = %q, want %q", got, "b\n")
+	}
+}
+
+func TestPipeline(t *testing.T) {
+	t.Parallel()
+
+	var mapped []string
+	var p Pipeline
+	p.Use(func(rec []string) ([]string, error) {
+		if rec[2] == "0" {
+			return nil, nil
+		}
+		return rec, nil
+	}).Use(func(rec []string) ([]string, error) {
+		mapped = append(mapped, rec[0])
+		return []string{rec[1], rec[2] + "kg"}, nil
+	})
+
+	var buf bytes.Buffer
+	err := p.Run(NewReader(strings.NewReader("id,name,qty\n1,apple,3\n2,pear,0\n3,plum,7\n")), NewWriter(&buf))
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if want := "name,qtykg\napple,3kg\nplum,7kg\n"; buf.String() != want {
+		t.Fatalf("unexpected output:\n got: %q\nwant: %q", buf.String(), want)
+	}
+	if want := []string{"id", "1", "3"}; !reflect.DeepEqual(mapped, want) {
+		t.Fatalf("map stage saw %v, want %v", mapped, want)
+	}
+}
+
+func TestPipelineStageError(t *testing.T) {
+	t.Parallel()
+
+	exp := errors.New("bad row")
+	calledLast := 0
+	var p Pipeline
+	p.Use(func(rec []string) ([]string, error) { return rec, nil })
+	p.Use(func(rec []string) ([]string, error) {
+		if rec[0] == "b" {
+			return nil, exp
+		}
+		return rec, nil
+	})
+	p.Use(func(rec []string) ([]string, error) {
+		calledLast++
+		return rec, nil
+	})
+
+	var buf bytes.Buffer
+	err := p.Run(NewReader(strings.NewReader("a\nb\nc\n")), NewWriter(&buf))
+	if !errors.Is(err, exp) {
+		t.Fatalf("Run() error = %v, want %v", err, exp)
+	}
+	if calledLast != 1 || buf.String() != "a\n" {
+		t.Fatalf("last stage calls = %d, output = %q; want 1, %q", calledLast, buf.String(), "a\n")
+	}
+}
+
+func TestPipelineEmpty(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	var p Pipeline
+	if err := p.Run(NewReader(strings.NewReader("a,b\n")), NewWriter(&buf)); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if buf.String() != "a,b\n" {
+		t.Fatalf("unexpected output %q", buf.String())
+	}
+}
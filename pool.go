@@ -0,0 +1,61 @@
+package swiftcsv
+
+import (
+	"io"
+	"sync"
+)
+
+// readerPool holds readers returned by PutReader so their buffers can be reused.
+var readerPool = sync.Pool{
+	New: func() any { return new(Reader) },
+}
+
+// GetReader returns a Reader for src configured exactly as NewReader would, reusing the
+// internal buffers of a reader previously released with PutReader when one is available.
+// It panics if src is nil.
+func GetReader(src io.Reader) *Reader {
+	if src == nil {
+		panic("swiftcsv: reader source cannot be nil")
+	}
+
+	r := readerPool.Get().(*Reader)
+	if r.buf == nil {
+		// A fresh reader from the pool: allocate the same storage NewReader does.
+		*r = *NewReader(src)
+		return r
+	}
+	r.src = src
+	r.Comma = ','
+	r.Quote = '"'
+	r.line = 1
+	return r
+}
+
+// PutReader releases r to the pool used by GetReader. All configuration and state are reset
+// and the recycled buffers are zeroed, so no data from r can be observed by a later user. r
+// and any record it returned with ReuseRecord set must not be used after the call.
+func PutReader(r *Reader) {
+	if r == nil || r.buf == nil {
+		return
+	}
+
+	buf := r.buf[:cap(r.buf)]
+	clear(buf)
+	// Without ReuseRecord the last record was handed to the caller, so it is left alone.
+	var record []string
+	if r.ReuseRecord {
+		record = r.record[:cap(r.record)]
+		clear(record)
+	}
+	dataBuf := r.dataBuf[:cap(r.dataBuf)]
+	clear(dataBuf)
+	fieldBounds := r.fieldBounds[:0]
+
+	*r = Reader{
+		buf:         buf,
+		record:      record[:0],
+		dataBuf:     dataBuf[:0],
+		fieldBounds: fieldBounds,
+	}
+	readerPool.Put(r)
+}
@@ -0,0 +1,106 @@
+package swiftcsv
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestGetPutReaderCycle(t *testing.T) {
+	r := GetReader(strings.NewReader("# secret note\nuser;password\nalice;hunter2\n"))
+	r.Comma = ';'
+	r.Comment = '#'
+	r.ReuseRecord = true
+	if _, err := r.ReadHeader(); err != nil {
+		t.Fatalf("ReadHeader() error = %v", err)
+	}
+	if _, err := r.Read(); err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	PutReader(r)
+
+	for name, b := range map[string][]byte{"buf": r.buf, "dataBuf": r.dataBuf[:cap(r.dataBuf)]} {
+		if strings.Contains(string(b), "hunter2") {
+			t.Fatalf("%s still holds record data after PutReader", name)
+		}
+	}
+	for i, s := range r.record[:cap(r.record)] {
+		if s != "" {
+			t.Fatalf("record[%d] = %q after PutReader, want empty", i, s)
+		}
+	}
+	if r.Comma != 0 || r.Comment != 0 || r.ReuseRecord || r.Header() != nil || r.Comments() != nil {
+		t.Fatalf("PutReader() left configuration behind: %+v", r)
+	}
+
+	for i := 0; i < 3; i++ {
+		r := GetReader(strings.NewReader("a,b\n\"c\",d\n"))
+		got, err := r.ReadAll()
+		if err != nil {
+			t.Fatalf("cycle %d: ReadAll() error = %v", i, err)
+		}
+		if want := [][]string{{"a", "b"}, {"c", "d"}}; !reflect.DeepEqual(got, want) {
+			t.Fatalf("cycle %d: ReadAll() = %#v, want %#v", i, got, want)
+		}
+		if r.Header() != nil || len(r.Comments()) != 0 {
+			t.Fatalf("cycle %d: recycled reader reports header %v and comments %v", i, r.Header(), r.Comments())
+		}
+		PutReader(r)
+	}
+
+	PutReader(nil)
+}
+
+func TestGetReaderNilSource(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("GetReader(nil) did not panic")
+		}
+	}()
+	GetReader(nil)
+}
+
+func TestReaderPoolConcurrent(t *testing.T) {
+	t.Parallel()
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				id := strconv.Itoa(g*1000 + i)
+				r := GetReader(strings.NewReader(id + ",\"" + strings.Repeat(id, 300) + "\"\n"))
+				rec, err := r.Read()
+				if err != nil {
+					t.Errorf("Read() error = %v", err)
+					return
+				}
+				if rec[0] != id || rec[1] != strings.Repeat(id, 300) {
+					t.Errorf("Read() = %.40q, want record for %s", rec, id)
+					return
+				}
+				PutReader(r)
+			}
+		}(g)
+	}
+	wg.Wait()
+}
+
+func TestPutReaderKeepsReturnedRecord(t *testing.T) {
+	t.Parallel()
+
+	r := GetReader(strings.NewReader("a,b\n"))
+	record, err := r.Read()
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	PutReader(r)
+	if want := []string{"a", "b"}; !reflect.DeepEqual(record, want) {
+		t.Fatalf("record after PutReader = %q, want %q", record, want)
+	}
+}
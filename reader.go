@@ -2,9 +2,13 @@ package swiftcsv
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io"
+	"iter"
+	"slices"
+	"unicode/utf8"
 	"unsafe"
 )
 
@@ -17,6 +21,18 @@ var (
 	ErrUnterminatedQuote = errors.New("swiftcsv: unterminated quoted field")
 	// ErrorFieldCount is returned when a record contains an unexpected number of fields.
 	ErrorFieldCount = errors.New("swiftcsv: wrong number of fields")
+	// ErrInvalidDelimiter is returned when the delimiter or quote is zero, a line terminator, or they collide.
+	ErrInvalidDelimiter = errors.New("swiftcsv: invalid field delimiter or quote character")
+	// ErrNULByte is returned when Reader.RejectNUL is set and a field contains a NUL byte.
+	ErrNULByte = errors.New("swiftcsv: NUL byte in field")
+	// ErrFieldTooLarge is returned when a field grows beyond Reader.MaxFieldSize bytes.
+	ErrFieldTooLarge = errors.New("swiftcsv: field exceeds maximum size")
+	// ErrLineTooLong is returned when a record grows beyond Reader.MaxLineLength bytes.
+	ErrLineTooLong = errors.New("swiftcsv: line exceeds maximum length")
+	// ErrMultipleRecords is returned by ParseRecord when its input holds more than one record.
+	ErrMultipleRecords = errors.New("swiftcsv: input holds more than one record")
+
+	errNilSource = errors.New("swiftcsv: reader source cannot be nil")
 )
 
 // ParseError contains location information for CSV parsing errors.
@@ -42,6 +58,41 @@ func (e *ParseError) Unwrap() error {
 	return e.Err
 }
 
+// FieldCountError describes a record whose width differs from FieldsPerRecord. It is reported
+// as the Err of a *ParseError and matches ErrorFieldCount under errors.Is.
+type FieldCountError struct {
+	Expected int
+	Got      int
+}
+
+// Error formats the expected and actual field counts.
+func (e *FieldCountError) Error() string {
+	if e == nil {
+		return ""
+	}
+	return fmt.Sprintf("%v: expected %d, got %d", ErrorFieldCount, e.Expected, e.Got)
+}
+
+// Unwrap returns ErrorFieldCount so callers can keep matching the sentinel.
+func (e *FieldCountError) Unwrap() error {
+	return ErrorFieldCount
+}
+
+// LazyQuoteMode selects how a Reader with LazyQuotes set interprets a quote that appears inside
+// an unquoted field.
+type LazyQuoteMode int
+
+const (
+	// LazyQuoteLiteral keeps the quote as ordinary field data, so a"b"c reads as a"b"c and
+	// a"b,c"d as the two fields a"b and c"d. It is the zero value.
+	LazyQuoteLiteral LazyQuoteMode = iota
+	// LazyQuoteClose treats the quote as opening a quoted section that the next quote closes,
+	// dropping both quotes, so a"b"c reads as abc and a"b,c"d as the single field ab,cd. Within
+	// the section delimiters, line breaks, and doubled quotes behave as in a quoted field, and
+	// a section still open at the end of input fails with ErrUnterminatedQuote.
+	LazyQuoteClose
+)
+
 // Reader provides high-performance CSV parsing with support for customizable delimiters.
 type Reader struct {
 	src io.Reader
@@ -50,10 +101,117 @@ type Reader struct {
 	Comma byte
 	// Quote is the quote character. Default is '"'.
 	Quote byte
+	// CommaRune, when non-zero, replaces Comma with an arbitrary Unicode delimiter matched
+	// against its UTF-8 encoding. ASCII values use the same byte fast path as Comma.
+	CommaRune rune
+	// QuoteRune, when non-zero, replaces Quote with an arbitrary Unicode quote character.
+	QuoteRune rune
+	// QuoteOpen and QuoteClose, when non-zero, replace Quote as the bytes that begin and end a
+	// quoted field; either one left zero defaults to the quote in effect. When they differ, as
+	// with '[' and ']', the close byte always ends the field: there is no doubling escape, so a
+	// quoted field cannot contain it. Both must be ASCII and cannot be combined with a
+	// multi-byte QuoteRune.
+	QuoteOpen  byte
+	QuoteClose byte
 	// ReuseRecord indicates whether Read should reuse the backing array of the returned slice.
 	ReuseRecord bool
 	// FieldsPerRecord expects each record to contain this many fields. Zero captures the width of the first record.
 	FieldsPerRecord int
+	// HeaderDefinesWidth requires every record to have as many fields as the header installed by
+	// ReadHeader or SetHeader, overriding FieldsPerRecord. Until a header is in effect, the first
+	// record read is treated as the header and sets the width.
+	HeaderDefinesWidth bool
+	// LazyQuotes accepts a quote inside an unquoted field, which otherwise fails with
+	// ErrBareQuote, and interprets it according to LazyQuoteMode.
+	LazyQuotes bool
+	// LazyQuoteMode chooses the recovery strategy used by LazyQuotes. The default is
+	// LazyQuoteLiteral.
+	LazyQuoteMode LazyQuoteMode
+	// AllowLeadingBlankQuote lets a quote open a quoted field when only spaces or tabs precede it
+	// within the field. The leading blanks are discarded in that case.
+	AllowLeadingBlankQuote bool
+	// CollapseDelimiters treats a run of adjacent delimiters outside quotes as a single separator,
+	// so no empty fields are produced between them. A run at the start of a record still ends an
+	// empty first field. This suits whitespace-aligned data read with Comma set to ' '.
+	CollapseDelimiters bool
+	// RejectNUL makes Read fail with ErrNULByte when a field contains a NUL (0x00) byte.
+	// By default NUL bytes are passed through unchanged.
+	RejectNUL bool
+	// TrimSpace removes leading and trailing spaces and tabs from unquoted fields. Quoted field
+	// contents are kept literally unless TrimInsideQuotes is also set.
+	TrimSpace bool
+	// TrimInsideQuotes extends TrimSpace to the contents of quoted fields. It has no effect
+	// without TrimSpace or when KeepQuotes is set.
+	TrimInsideQuotes bool
+	// RecordTerminators, when non-empty, replaces \n and \r as the bytes that end a record
+	// outside quotes, for example "\x1e" for ASCII record separators. \n and \r keep their
+	// meaning only when listed, and a \r\n pair ends a single record when both are. Other line
+	// breaks outside quotes become field data. Each terminator advances the line count.
+	// SkipLines, Comment, and ReadLine still work on \n and \r lines. The terminators must
+	// differ from the delimiter and the quote.
+	RecordTerminators []byte
+	// NormalizeNewlines stores every line break inside a quoted field as \n, converting \r\n and
+	// lone \r, so field contents do not depend on the line endings of the source.
+	NormalizeNewlines bool
+	// KeepQuotes returns quoted fields exactly as written, including the enclosing quotes and
+	// doubled escape quotes, instead of their unquoted values. Delimiters and line breaks inside
+	// the quotes are still treated as field data.
+	KeepQuotes bool
+	// HeaderTransform, when non-nil, rewrites each header name installed by ReadHeader or SetHeader,
+	// for example to normalise case. It runs before names are indexed for ColumnIndex and ReadMap.
+	HeaderTransform func(string) string
+	// SkipLines discards this many physical lines before the first record. Skipped lines are not
+	// parsed as CSV, so they may contain arbitrary text, but they still advance the line counter.
+	SkipLines int
+	// MaxFieldSize caps the number of bytes buffered for a single field. Zero means unlimited.
+	MaxFieldSize int
+	// MaxLineLength caps the number of source bytes in a single logical line, that is a record
+	// including any line breaks inside quoted fields but excluding its terminator. A longer
+	// record fails with ErrLineTooLong and ends the input; at most one buffer beyond the limit
+	// is read before the error is reported. Zero means unlimited.
+	MaxLineLength int
+	// CaptureRaw keeps the source bytes of each record for LastRecordRaw. It is off by default
+	// because records that straddle a buffer refill have to be copied aside.
+	CaptureRaw bool
+	// Comment, when non-zero, marks lines beginning with this byte as comments. They are skipped
+	// instead of parsed and their text, without the prefix, is collected for Comments. Comment
+	// must differ from the delimiter, the quote, and the line terminators.
+	Comment byte
+	// DecimalSeparator, when non-zero, is the decimal mark used by ReadTyped and InferSchema when
+	// reading floats, such as ',' for European data. It does not affect field splitting.
+	DecimalSeparator byte
+	// NullText, when non-empty, is the sentinel that ReadNullable maps to a nil field, such as
+	// \N for SQL NULL. Other read methods return it unchanged.
+	NullText string
+	// EOFOnBlankLine makes a line that is empty or holds only spaces and tabs end the current
+	// section: Read returns io.EOF instead of a record. Reading again continues with the next
+	// section, skipping any further blank lines, so multi-section files can be consumed in turn.
+	// Blank lines inside quoted fields are field data and never end a section.
+	EOFOnBlankLine bool
+	// MaxRecords, when positive, limits the number of records returned: once that many have been
+	// read, further reads report io.EOF even if input remains. Zero means unlimited.
+	MaxRecords int
+	// Decoder, when non-nil, transcodes each parsed field from the source encoding to UTF-8, for
+	// example from ISO-8859-1. It receives the unquoted field bytes, which are only valid for the
+	// duration of the call, and its result is copied into the record.
+	Decoder func([]byte) []byte
+	// FieldTransform, when non-nil, is called with the column index and value of every field of
+	// a record returned by Read, and its result replaces the value, for example to normalise
+	// case. It runs after Decoder and TrimSpace. With ReuseRecord set, value may share storage
+	// with the next record, so the transform must not retain it; the result may differ in
+	// length and is stored as returned. ReadFields, GroupCount, ScanForFormulas, Diff, and
+	// MergeJoin see the transformed values too; ReadFunc and FieldBytes see the untransformed
+	// bytes.
+	FieldTransform func(index int, value string) string
+	// InternStrings makes records share a single string for every distinct field value, which
+	// saves memory when values such as country codes repeat heavily, at the cost of a map lookup
+	// per field. Interned values never alias reader storage, even with ReuseRecord. The table of
+	// distinct values lives as long as the reader, so high-cardinality columns grow it without
+	// bound.
+	InternStrings bool
+	// DedupeConsecutive skips records whose fields are identical to those of the record returned
+	// immediately before, so runs of repeated rows are reported once.
+	DedupeConsecutive bool
 
 	buf    []byte
 	bufPos int
@@ -62,9 +220,82 @@ type Reader struct {
 
 	record      []string
 	dataBuf     []byte
+	decodeBuf   []byte
 	fieldBounds []int
-	finished    bool
-	line        int
+	// quotedFields lists, in ascending order, the indexes of fields that were quoted.
+	quotedFields []int
+	lineBuf      []byte
+	header       []string
+	headerIndex  map[string]int
+	headerSet    bool
+	interned     map[string]string
+	comments     []string
+	pending      []pendingRecord
+	records      int
+	// widthTaken is set once HeaderDefinesWidth has taken the width from a first record.
+	widthTaken bool
+	// minFields, maxFields, and quotedCount accumulate the record statistics reported by Stats.
+	minFields   int
+	maxFields   int
+	quotedCount int64
+	// sectionBreak is set after EOFOnBlankLine reported a section end.
+	sectionBreak bool
+
+	// The raw bytes of the last record are rawBuf followed by buf[rawMark:rawEnd]. rawBuf only
+	// collects the part of a record that was parsed before a buffer refill.
+	rawBuf    []byte
+	rawMark   int
+	rawEnd    int
+	capturing bool
+
+	// lastData and lastBounds retain the previous record for DedupeConsecutive.
+	lastData   []byte
+	lastBounds []int
+	hasLast    bool
+
+	// terminators is the byte set built from RecordTerminators while customTerminators is set.
+	terminators       [256]bool
+	customTerminators bool
+
+	lfCount   int
+	crlfCount int
+	crCount   int
+
+	// commaRest and quoteRest hold the UTF-8 continuation bytes of multi-byte delimiters
+	// and quoteSeq the complete quote encoding. They alias delimEnc, allocated on first use of
+	// a multi-byte delimiter, or byteSeqs, but never the Reader itself, which would force
+	// every Reader onto the heap.
+	delimEnc  []byte
+	commaRest []byte
+	quoteRest []byte
+	quoteSeq  []byte
+
+	ctx context.Context
+	// ctxErr is the cancellation that interrupted a partly read record; it ends the input.
+	ctxErr     error
+	sawInput   bool
+	skipped    int
+	finished   bool
+	line       int
+	recordLine int
+
+	// srcBytes counts the bytes obtained from src; recordStart and recordEnd delimit the
+	// current record as offsets into the stream, and termLen is the length of the terminator
+	// that ended it.
+	srcBytes    int64
+	recordStart int64
+	recordEnd   int64
+	termLen     int64
+}
+
+// pendingRecord is a parsed record queued for replay by readRecord.
+type pendingRecord struct {
+	data       []byte
+	bounds     []int
+	quoted     []int
+	raw        []byte
+	line       int
+	start, end int64
 }
 
 // NewReader creates a Reader that consumes CSV data from r, panicking if r is nil,
@@ -87,6 +318,57 @@ func NewReader(r io.Reader) *Reader {
 	}
 }
 
+// NewReaderWithColumns behaves like NewReader but presizes the per-record storage for
+// expectedColumns fields, avoiding repeated growth while parsing the first record of very
+// wide inputs. Non-positive values fall back to the NewReader defaults.
+func NewReaderWithColumns(r io.Reader, expectedColumns int) *Reader {
+	rd := NewReader(r)
+	if expectedColumns > cap(rd.record) {
+		rd.record = make([]string, 0, expectedColumns)
+	}
+	if 2*expectedColumns > cap(rd.fieldBounds) {
+		rd.fieldBounds = make([]int, 0, 2*expectedColumns)
+	}
+	return rd
+}
+
+// NewReaderAt creates a Reader that parses only the length bytes of ra starting at off, for
+// example a record span recorded with ReadWithOffset. Offsets reported by the returned Reader
+// are relative to off. It panics if ra is nil.
+func NewReaderAt(ra io.ReaderAt, off, length int64) *Reader {
+	if ra == nil {
+		panic("swiftcsv: reader source cannot be nil")
+	}
+	return NewReader(io.NewSectionReader(ra, off, length))
+}
+
+// ParseRecord parses line as exactly one CSV record using comma and quote, which default to
+// ',' and '"' when zero, and returns its fields as freshly allocated strings. A trailing line
+// terminator is optional, and an empty line yields a single empty field. An invalid comma and
+// quote pair fails with ErrInvalidDelimiter, parse failures are reported as a *ParseError, and
+// input that continues past the end of the first record fails
+// with a *ParseError wrapping ErrMultipleRecords.
+func ParseRecord(line []byte, comma, quote byte) ([]string, error) {
+	r := GetReader(bytes.NewReader(line))
+	defer PutReader(r)
+	r.Comma, r.Quote = comma, quote
+
+	record, err := r.Read()
+	if err == io.EOF {
+		return []string{""}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := r.readRecord(); err != io.EOF {
+		if err != nil {
+			return nil, err
+		}
+		return nil, &ParseError{Line: r.recordLine, Column: 1, Err: ErrMultipleRecords}
+	}
+	return record, nil
+}
+
 // Read parses the next CSV record from the underlying stream. It returns dst containing
 // the field values (which may reuse internal storage when ReuseRecord is true) and an err
 // indicating success or failure; io.EOF signals that no more records remain.
@@ -94,27 +376,292 @@ func (r *Reader) Read() (dst []string, err error) {
 	if r == nil || r.src == nil {
 		return nil, io.EOF
 	}
-	if r.finished {
+	if err := r.readRecord(); err != nil {
+		return nil, err
+	}
+	return r.buildRecord()
+}
+
+// ReadContext behaves like Read but returns ctx.Err() once ctx is done. Cancellation is checked
+// before the record starts and before every refill of the internal buffer; a blocking read on the
+// source that is already in flight cannot be interrupted unless the source itself honours
+// deadlines, such as a net.Conn with SetReadDeadline. Cancellation before any input of the
+// record was consumed leaves the reader usable, and a later call resumes at the same record.
+// Once a record has been partly consumed it cannot be resumed, so the reader stops: every later
+// read returns the context error.
+func (r *Reader) ReadContext(ctx context.Context) ([]string, error) {
+	if r == nil || r.src == nil {
 		return nil, io.EOF
 	}
+	if r.ctxErr != nil {
+		return nil, r.ctxErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.ctx = ctx
+	start := r.offset()
+	record, err := r.Read()
+	r.ctx = nil
+	if cerr := ctx.Err(); err != nil && cerr != nil && errors.Is(err, cerr) && r.offset() != start {
+		r.ctxErr = cerr
+	}
+	return record, err
+}
 
-	comma := r.Comma
-	if comma == 0 {
-		comma = ','
+// ReadFunc parses the next record and calls fn for each field in order with its bytes and
+// zero-based index, without building a []string. The field slice aliases the reader's
+// internal buffer and is only valid for the duration of the call. An error returned by fn
+// aborts the remaining fields and is returned unchanged; io.EOF signals that no records
+// remain. A FieldsPerRecord mismatch is reported after every field has been visited.
+func (r *Reader) ReadFunc(fn func(field []byte, index int) error) error {
+	if r == nil || r.src == nil {
+		return io.EOF
 	}
-	quote := r.Quote
-	if quote == 0 {
-		quote = '"'
+	if err := r.readRecord(); err != nil {
+		return err
 	}
+	fieldCount := len(r.fieldBounds) / 2
+	for i := 0; i < fieldCount; i++ {
+		start := r.fieldBounds[2*i]
+		end := r.fieldBounds[2*i+1]
+		if err := fn(r.dataBuf[start:end:end], i); err != nil {
+			return err
+		}
+	}
+	return r.checkFieldCount(fieldCount)
+}
 
-	// Reset state for assembling the next record, reusing slices when allowed.
-	if r.ReuseRecord {
-		r.record = r.record[:0]
-	} else {
-		r.record = nil
+// ReadFields parses the next record and returns an iterator over its zero-based field indexes
+// and values. Only the fields actually visited are converted to strings, so callers interested
+// in leading columns of wide rows can stop early; the reader is already positioned at the next
+// record either way. The iterator must be consumed before the next read, while the yielded
+// strings remain valid afterwards. A FieldsPerRecord mismatch is returned together with a
+// usable iterator; io.EOF signals that no records remain.
+func (r *Reader) ReadFields() (iter.Seq2[int, string], error) {
+	if r == nil || r.src == nil {
+		return nil, io.EOF
+	}
+	if err := r.readRecord(); err != nil {
+		return nil, err
+	}
+	fieldCount := len(r.fieldBounds) / 2
+	seq := func(yield func(int, string) bool) {
+		for i := 0; i < fieldCount && 2*i+1 < len(r.fieldBounds); i++ {
+			value := string(r.dataBuf[r.fieldBounds[2*i]:r.fieldBounds[2*i+1]])
+			if r.FieldTransform != nil {
+				value = r.FieldTransform(i, value)
+			}
+			if !yield(i, value) {
+				return
+			}
+		}
+	}
+	return seq, r.checkFieldCount(fieldCount)
+}
+
+// readRecord loads the next record into dataBuf and fieldBounds, leaving the construction of
+// field values to the caller. Pushed-back records are replayed before new input is parsed.
+func (r *Reader) readRecord() error {
+	if r.ctxErr != nil {
+		return r.ctxErr
+	}
+	if r.MaxRecords > 0 && r.records >= r.MaxRecords {
+		return io.EOF
+	}
+	if len(r.pending) > 0 {
+		p := r.pending[0]
+		r.pending = r.pending[1:]
+		r.dataBuf = append(r.dataBuf[:0], p.data...)
+		r.fieldBounds = append(r.fieldBounds[:0], p.bounds...)
+		r.quotedFields = append(r.quotedFields[:0], p.quoted...)
+		r.rawBuf = append(r.rawBuf[:0], p.raw...)
+		r.rawMark, r.rawEnd = 0, 0
+		r.recordLine = p.line
+		r.recordStart, r.recordEnd = p.start, p.end
+	} else if err := r.nextRecord(); err != nil {
+		return err
+	}
+	r.records++
+	n := len(r.fieldBounds) / 2
+	if r.records == 1 || n < r.minFields {
+		r.minFields = n
+	}
+	r.maxFields = max(r.maxFields, n)
+	r.quotedCount += int64(len(r.quotedFields))
+	return nil
+}
+
+// nextRecord parses the next record from the input, bypassing any pushed-back records. With
+// DedupeConsecutive set, records equal to the previous one are skipped.
+func (r *Reader) nextRecord() error {
+	for {
+		err := r.parseRecord()
+		if r.capturing {
+			r.rawEnd = r.bufPos
+			if len(r.rawBuf) > 0 {
+				r.keepRaw(r.bufPos)
+			}
+			r.capturing = false
+		}
+		if err != nil {
+			return err
+		}
+		r.recordEnd = r.offset()
+		if r.MaxLineLength > 0 {
+			if err := r.checkLineLength(r.recordEnd - r.recordStart - r.termLen); err != nil {
+				return err
+			}
+		}
+		if r.EOFOnBlankLine && r.blankLine() {
+			if r.sectionBreak {
+				// Further blank lines belong to the same separator.
+				continue
+			}
+			r.sectionBreak = true
+			return io.EOF
+		}
+		r.sectionBreak = false
+		if r.TrimSpace {
+			r.trimFields()
+		}
+		if r.Decoder != nil {
+			r.decodeFields()
+		}
+		if !r.DedupeConsecutive {
+			return nil
+		}
+		if r.sameAsLast() {
+			continue
+		}
+		r.lastData = append(r.lastData[:0], r.dataBuf...)
+		r.lastBounds = append(r.lastBounds[:0], r.fieldBounds...)
+		r.hasLast = true
+		return nil
+	}
+}
+
+// sameAsLast reports whether every field of the parsed record equals the previous record's.
+func (r *Reader) sameAsLast() bool {
+	if !r.hasLast || len(r.fieldBounds) != len(r.lastBounds) {
+		return false
+	}
+	for i := 0; i < len(r.fieldBounds); i += 2 {
+		field := r.dataBuf[r.fieldBounds[i]:r.fieldBounds[i+1]]
+		if !bytes.Equal(field, r.lastData[r.lastBounds[i]:r.lastBounds[i+1]]) {
+			return false
+		}
+	}
+	return true
+}
+
+// trimFields narrows the bounds of each field to exclude leading and trailing spaces and tabs.
+// Quoted fields are left alone unless TrimInsideQuotes is set and KeepQuotes is not.
+func (r *Reader) trimFields() {
+	trimQuoted := r.TrimInsideQuotes && !r.KeepQuotes
+	for i := 0; i < len(r.fieldBounds); i += 2 {
+		if !trimQuoted && r.FieldQuoted(i/2) {
+			continue
+		}
+		start, end := r.fieldBounds[i], r.fieldBounds[i+1]
+		for start < end && (r.dataBuf[start] == ' ' || r.dataBuf[start] == '\t') {
+			start++
+		}
+		for end > start && (r.dataBuf[end-1] == ' ' || r.dataBuf[end-1] == '\t') {
+			end--
+		}
+		r.fieldBounds[i], r.fieldBounds[i+1] = start, end
+	}
+}
+
+// blankLine reports whether the parsed record is a single unquoted field of spaces and tabs.
+func (r *Reader) blankLine() bool {
+	return len(r.fieldBounds) == 2 && len(r.quotedFields) == 0 && isBlank(r.dataBuf)
+}
+
+// pushBack queues a copy of the record currently in dataBuf and fieldBounds so the next
+// readRecord returns it again before any further input is parsed.
+func (r *Reader) pushBack() {
+	r.pending = append(r.pending, pendingRecord{
+		data:   slices.Clone(r.dataBuf),
+		bounds: slices.Clone(r.fieldBounds),
+		quoted: slices.Clone(r.quotedFields),
+		raw:    slices.Clone(r.LastRecordRaw()),
+		line:   r.recordLine,
+		start:  r.recordStart,
+		end:    r.recordEnd,
+	})
+}
+
+// decodeFields replaces every field of the parsed record with its Decoder output.
+func (r *Reader) decodeFields() {
+	out := r.decodeBuf[:0]
+	for i := 0; i < len(r.fieldBounds); i += 2 {
+		start, end := r.fieldBounds[i], r.fieldBounds[i+1]
+		r.fieldBounds[i] = len(out)
+		out = append(out, r.Decoder(r.dataBuf[start:end:end])...)
+		r.fieldBounds[i+1] = len(out)
+	}
+	r.decodeBuf, r.dataBuf = r.dataBuf, out
+}
+
+// parseRecord parses a single record into dataBuf and fieldBounds.
+func (r *Reader) parseRecord() error {
+	r.rawBuf = r.rawBuf[:0]
+	r.rawMark, r.rawEnd = 0, 0
+	if r.finished {
+		return io.EOF
+	}
+	// Discard leading junk lines verbatim before the first record.
+	for r.skipped < r.SkipLines {
+		if _, err := r.ReadLine(); err != nil {
+			return err
+		}
+		r.skipped++
+	}
+
+	comma, quote, ok := r.delimiters()
+	if !ok {
+		return ErrInvalidDelimiter
+	}
+	var closeQuote byte
+	if quote, closeQuote, ok = r.quotePair(comma, quote); !ok {
+		return ErrInvalidDelimiter
 	}
+	r.customTerminators = len(r.RecordTerminators) > 0
+	if r.customTerminators {
+		r.terminators = [256]bool{}
+		for _, c := range r.RecordTerminators {
+			if c == comma || c == quote || c == closeQuote {
+				return ErrInvalidDelimiter
+			}
+			r.terminators[c] = true
+		}
+	}
+	commaRest, quoteRest := r.commaRest, r.quoteRest
+	if r.Comment != 0 {
+		if c := r.Comment; c == comma || c == quote || c == closeQuote || c == '\n' || c == '\r' {
+			return ErrInvalidDelimiter
+		}
+		if err := r.skipComments(); err != nil {
+			return err
+		}
+		if r.finished {
+			return io.EOF
+		}
+	}
+
+	// Reset state for assembling the next record.
 	r.dataBuf = r.dataBuf[:0]
 	r.fieldBounds = r.fieldBounds[:0]
+	r.quotedFields = r.quotedFields[:0]
+	r.recordLine = r.line
+	r.recordStart = r.offset()
+	r.termLen = 0
+	if r.CaptureRaw {
+		r.rawMark = r.bufPos
+		r.capturing = true
+	}
 
 	inQuotes := false
 	sawQuotedField := false
@@ -132,22 +679,26 @@ func (r *Reader) Read() (dst []string, err error) {
 					// Unterminated quotes at EOF are invalid.
 					if inQuotes {
 						r.finished = true
-						return nil, r.wrapError(curColumn, ErrUnterminatedQuote)
+						return r.wrapError(curColumn, ErrUnterminatedQuote)
 					}
 					// Flush a trailing field if data ended without a newline.
 					if len(r.fieldBounds) > 0 || len(r.dataBuf) > 0 || sawQuotedField {
 						r.fieldBounds = append(r.fieldBounds, fieldStart, len(r.dataBuf))
 						r.finished = true
-						return r.buildRecord()
+						return nil
 					}
 					r.finished = true
-					return nil, io.EOF
+					return io.EOF
 				}
-				return nil, err
+				return err
 			}
 
+			if err := r.checkLineLength(r.offset() - r.recordStart); err != nil {
+				return err
+			}
 			// Pull the next chunk from the source.
-			n, err := r.src.Read(r.buf)
+			r.keepRaw(r.bufLen)
+			n, err := r.fill(r.buf)
 			if n == 0 {
 				if err != nil {
 					r.bufErr = err
@@ -156,6 +707,7 @@ func (r *Reader) Read() (dst []string, err error) {
 			}
 			r.bufPos = 0
 			r.bufLen = n
+			r.rawMark = 0
 			r.bufErr = err
 		}
 
@@ -170,27 +722,25 @@ func (r *Reader) Read() (dst []string, err error) {
 			switch {
 			case quoteIdx == -1:
 				// Consume plain bytes, returning early if we closed a record.
-				recordDone, err := r.consumePlain(&column, &fieldStart, &sawQuotedField)
+				recordDone, err := r.consumePlain(comma, r.bufLen, &column, &fieldStart, &sawQuotedField)
 				if err != nil {
-					return nil, err
+					return err
 				}
 				if recordDone {
-					return r.buildRecord()
+					return nil
 				}
 				if r.bufPos >= r.bufLen {
 					continue
 				}
 			case quoteIdx > 0:
-				// Temporarily limit the buffer to process plain bytes up to the quote.
-				originalLen := r.bufLen
-				r.bufLen = r.bufPos + quoteIdx
-				recordDone, err := r.consumePlain(&column, &fieldStart, &sawQuotedField)
-				r.bufLen = originalLen
+				// Process plain bytes up to the quote. The window is passed explicitly rather than
+				// by shrinking bufLen, so a \r lookahead at its edge sees the real buffer.
+				recordDone, err := r.consumePlain(comma, r.bufPos+quoteIdx, &column, &fieldStart, &sawQuotedField)
 				if err != nil {
-					return nil, err
+					return err
 				}
 				if recordDone {
-					return r.buildRecord()
+					return nil
 				}
 				if r.bufPos >= r.bufLen {
 					continue
@@ -203,27 +753,98 @@ func (r *Reader) Read() (dst []string, err error) {
 		r.bufPos++
 
 		if inQuotes {
-			if b == quote {
-				// Double quote inside quotes represents an escaped quote.
-				next, err := r.peekByte()
+			if b == quote && len(quoteRest) > 0 {
+				matched, err := r.matchSeq(quoteRest)
+				if err != nil {
+					return err
+				}
+				if matched {
+					r.bufPos += len(quoteRest)
+					// A doubled multi-byte quote represents an escaped quote.
+					doubled, err := r.matchSeq(r.quoteSeq)
+					if err != nil {
+						return err
+					}
+					if doubled {
+						r.bufPos += len(r.quoteSeq)
+						if r.KeepQuotes {
+							r.dataBuf = append(r.dataBuf, r.quoteSeq...)
+						}
+						r.dataBuf = append(r.dataBuf, r.quoteSeq...)
+						column = curColumn + 2*len(r.quoteSeq)
+						if err := r.checkFieldSize(fieldStart, column); err != nil {
+							return err
+						}
+						continue
+					}
+					if r.KeepQuotes {
+						r.dataBuf = append(r.dataBuf, r.quoteSeq...)
+					}
+					inQuotes = false
+					column = curColumn + len(r.quoteSeq)
+					continue
+				}
+				// The lead byte is ordinary data; matchSeq may have compacted buf, so append b directly.
+				r.dataBuf = append(r.dataBuf, b)
+				column = curColumn + 1
+				if err := r.checkFieldSize(fieldStart, column); err != nil {
+					return err
+				}
+				continue
+			} else if b == closeQuote {
+				// Double quote inside quotes represents an escaped quote; asymmetric pairs have no escape.
+				var next byte
+				var err error
+				if closeQuote == quote {
+					next, err = r.peekByte()
+				}
 				if err == nil && next == quote {
 					r.bufPos++
+					if r.KeepQuotes {
+						r.dataBuf = append(r.dataBuf, quote)
+					}
 					r.dataBuf = append(r.dataBuf, quote)
 					column = curColumn + 2
+					if err := r.checkFieldSize(fieldStart, column); err != nil {
+						return err
+					}
 					continue
 				}
 				if err != nil && err != io.EOF {
-					return nil, err
+					return err
+				}
+				if r.KeepQuotes {
+					r.dataBuf = append(r.dataBuf, closeQuote)
 				}
 				inQuotes = false
 				column = curColumn + 1
 				continue
 			}
-			if b == '\n' {
-				// Track logical line numbers for embedded newlines.
+			if b == '\n' || b == '\r' {
+				// Track logical line numbers for embedded line breaks. The bytes are kept verbatim
+				// unless NormalizeNewlines is set; a \r followed by \n is counted once, when the \n
+				// is consumed.
+				if b == '\r' {
+					next, err := r.peekByte()
+					if err != nil && err != io.EOF {
+						return err
+					}
+					if err == nil && next == '\n' {
+						if !r.NormalizeNewlines {
+							r.dataBuf = append(r.dataBuf, b)
+						}
+						continue
+					}
+					if r.NormalizeNewlines {
+						b = '\n'
+					}
+				}
 				r.dataBuf = append(r.dataBuf, b)
 				r.line++
 				column = 1
+				if err := r.checkFieldSize(fieldStart, column); err != nil {
+					return err
+				}
 				continue
 			}
 
@@ -233,7 +854,7 @@ func (r *Reader) Read() (dst []string, err error) {
 				data := r.buf[r.bufPos:r.bufLen]
 				for i := 0; i < len(data); i++ {
 					c := data[i]
-					if c == quote || c == '\n' {
+					if c == closeQuote || c == '\n' || c == '\r' {
 						break
 					}
 					run++
@@ -241,45 +862,109 @@ func (r *Reader) Read() (dst []string, err error) {
 				r.bufPos += run - 1
 			}
 			column = curColumn + run
+			if err := r.checkNUL(r.buf[start:start+run], curColumn); err != nil {
+				return err
+			}
 			// Append contiguous plain bytes within the quoted field.
 			r.dataBuf = append(r.dataBuf, r.buf[start:start+run]...)
+			if err := r.checkFieldSize(fieldStart, column); err != nil {
+				return err
+			}
 			continue
 		}
 
-		switch b {
-		case comma:
+		isComma, isQuote := b == comma, b == quote
+		width := 1
+		if (isComma && len(commaRest) > 0) || (isQuote && len(quoteRest) > 0) {
+			// Multi-byte delimiters only match when their continuation bytes follow the lead byte.
+			var err error
+			if isComma && len(commaRest) > 0 {
+				if isComma, err = r.matchSeq(commaRest); err != nil {
+					return err
+				}
+			}
+			if isQuote && len(quoteRest) > 0 {
+				if isComma {
+					isQuote = false
+				} else if isQuote, err = r.matchSeq(quoteRest); err != nil {
+					return err
+				}
+			}
+			switch {
+			case isComma:
+				r.bufPos += len(commaRest)
+				width += len(commaRest)
+			case isQuote:
+				r.bufPos += len(quoteRest)
+				width += len(quoteRest)
+			default:
+				r.dataBuf = append(r.dataBuf, b)
+				column = curColumn + 1
+				if err := r.checkFieldSize(fieldStart, column); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+
+		switch {
+		case isComma:
+			column = curColumn + width
+			if r.collapseDelimiter(fieldStart, sawQuotedField) {
+				break
+			}
 			r.fieldBounds = append(r.fieldBounds, fieldStart, len(r.dataBuf))
 			fieldStart = len(r.dataBuf)
 			sawQuotedField = false
-			column = curColumn + 1
-		case '\n':
-			r.fieldBounds = append(r.fieldBounds, fieldStart, len(r.dataBuf))
-			sawQuotedField = false
-			r.line++
-			column = 1
-			return r.buildRecord()
-		case '\r':
-			next, err := r.peekByte()
-			if err == nil && next == '\n' {
-				r.bufPos++
-			}
-			if err != nil && err != io.EOF {
-				return nil, err
+		case r.isTerminator(b):
+			if err := r.consumeTerminator(b); err != nil {
+				return err
 			}
 			r.fieldBounds = append(r.fieldBounds, fieldStart, len(r.dataBuf))
 			sawQuotedField = false
 			r.line++
 			column = 1
-			return r.buildRecord()
-		case quote:
+			return nil
+		case isQuote:
 			// A quote starts a quoted field only if we have not buffered any characters yet.
 			if len(r.dataBuf) == fieldStart && !sawQuotedField {
+				if r.KeepQuotes {
+					r.dataBuf = append(r.dataBuf, r.quoteSeq...)
+				}
 				inQuotes = true
 				sawQuotedField = true
-				column = curColumn + 1
+				r.quotedFields = append(r.quotedFields, len(r.fieldBounds)/2)
+				column = curColumn + width
+				continue
+			}
+			if r.AllowLeadingBlankQuote && !sawQuotedField && isBlank(r.dataBuf[fieldStart:]) {
+				r.dataBuf = r.dataBuf[:fieldStart]
+				if r.KeepQuotes {
+					r.dataBuf = append(r.dataBuf, r.quoteSeq...)
+				}
+				inQuotes = true
+				sawQuotedField = true
+				r.quotedFields = append(r.quotedFields, len(r.fieldBounds)/2)
+				column = curColumn + width
+				continue
+			}
+			if r.LazyQuotes {
+				column = curColumn + width
+				if r.LazyQuoteMode == LazyQuoteClose {
+					// Quote the rest of the field up to the next quote, which closes it again.
+					if r.KeepQuotes {
+						r.dataBuf = append(r.dataBuf, r.quoteSeq...)
+					}
+					inQuotes = true
+					continue
+				}
+				r.dataBuf = append(r.dataBuf, r.quoteSeq...)
+				if err := r.checkFieldSize(fieldStart, column); err != nil {
+					return err
+				}
 				continue
 			}
-			return nil, r.wrapError(curColumn, ErrBareQuote)
+			return r.wrapError(curColumn, ErrBareQuote)
 		default:
 			start := r.bufPos - 1
 			run := 1
@@ -287,7 +972,7 @@ func (r *Reader) Read() (dst []string, err error) {
 				data := r.buf[r.bufPos:r.bufLen]
 				for i := 0; i < len(data); i++ {
 					c := data[i]
-					if c == comma || c == '\n' || c == '\r' || c == quote {
+					if c == comma || c == quote || r.isTerminator(c) {
 						break
 					}
 					run++
@@ -295,15 +980,281 @@ func (r *Reader) Read() (dst []string, err error) {
 				r.bufPos += run - 1
 			}
 			column = curColumn + run
+			if err := r.checkNUL(r.buf[start:start+run], curColumn); err != nil {
+				return err
+			}
 			// Copy consecutive plain bytes before the next delimiter.
 			r.dataBuf = append(r.dataBuf, r.buf[start:start+run]...)
+			if err := r.checkFieldSize(fieldStart, column); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// LineEndingStats reports how many record terminators of each kind have been consumed so far:
+// lone \n, \r\n pairs, and lone \r. Newlines embedded in quoted fields are field data and are
+// not counted. The counts accumulate across Read and ReadLine calls and may be queried at any time.
+func (r *Reader) LineEndingStats() (lf, crlf, cr int) {
+	if r == nil {
+		return 0, 0, 0
+	}
+	return r.lfCount, r.crlfCount, r.crCount
+}
+
+// ReaderStats summarises the records a Reader has returned so far.
+type ReaderStats struct {
+	// Records is the number of records returned, including those skipped by SkipRecords.
+	Records int64
+	// Bytes is the number of source bytes parsed, including skipped lines and comments but not
+	// input that is buffered and not yet parsed.
+	Bytes int64
+	// MinFields and MaxFields are the smallest and largest field counts of the records returned.
+	// Both are zero before the first record.
+	MinFields int
+	MaxFields int
+	// QuotedFields is the number of quoted fields among the records returned.
+	QuotedFields int64
+}
+
+// Stats returns the statistics accumulated since the reader was created. It may be called at
+// any time, including after io.EOF.
+func (r *Reader) Stats() ReaderStats {
+	if r == nil {
+		return ReaderStats{}
+	}
+	return ReaderStats{
+		Records:      int64(r.records),
+		Bytes:        r.offset(),
+		MinFields:    r.minFields,
+		MaxFields:    r.maxFields,
+		QuotedFields: r.quotedCount,
+	}
+}
+
+// Comments returns the text of every comment line skipped so far, in input order and without
+// the Comment prefix. It is nil when Comment is unset or no comment has been seen.
+func (r *Reader) Comments() []string {
+	if r == nil {
+		return nil
+	}
+	return r.comments
+}
+
+// skipComments consumes consecutive comment lines at the start of a record, collecting their text.
+func (r *Reader) skipComments() error {
+	for {
+		b, err := r.peekByte()
+		if err != nil {
+			// Leave the error for the record loop, which owns EOF handling.
+			if r.bufErr == nil {
+				r.bufErr = err
+			}
+			return nil
+		}
+		if b != r.Comment {
+			return nil
+		}
+		line, err := r.ReadLine()
+		if err != nil {
+			return err
+		}
+		r.comments = append(r.comments, string(line[1:]))
+	}
+}
+
+// Empty reports whether the stream has been exhausted without producing a single byte, which
+// distinguishes a completely empty source from one whose records have all been read. It is only
+// meaningful after Read has returned io.EOF; input consisting solely of whitespace is not empty.
+func (r *Reader) Empty() bool {
+	if r == nil {
+		return false
+	}
+	return r.finished && !r.sawInput
+}
+
+// LastRecordRaw returns the source bytes of the most recently read record exactly as they
+// appeared, including quotes and its line terminator, when CaptureRaw is set, and nil
+// otherwise. The slice is owned by the reader and is only valid until the next read.
+func (r *Reader) LastRecordRaw() []byte {
+	if r == nil {
+		return nil
+	}
+	if !r.CaptureRaw {
+		return nil
+	}
+	if len(r.rawBuf) > 0 {
+		return r.rawBuf
+	}
+	return r.buf[r.rawMark:r.rawEnd]
+}
+
+// FieldQuoted reports whether field i of the most recently read record was enclosed in quotes
+// in the input. It returns false for indexes outside the record.
+func (r *Reader) FieldQuoted(i int) bool {
+	if r == nil {
+		return false
+	}
+	_, found := slices.BinarySearch(r.quotedFields, i)
+	return found
+}
+
+// ReadWithOffset behaves like Read and additionally returns the byte range the record occupies
+// in the source: start is the offset of its first byte and end the offset just past its line
+// terminator, so seeking a copy of the source to start and reading end-start bytes yields the
+// raw record. Offsets count from the reader's first byte, including skipped and comment lines.
+func (r *Reader) ReadWithOffset() (fields []string, start, end int64, err error) {
+	fields, err = r.Read()
+	if err == io.EOF {
+		return nil, 0, 0, err
+	}
+	return fields, r.recordStart, r.recordEnd, err
+}
+
+// FieldBytes returns field i of the most recently read record as a sub-slice of the reader's
+// internal data buffer, or nil when i is out of range. The slice aliases storage that the next
+// call to Read overwrites, and with ReuseRecord enabled the returned strings share the same bytes,
+// so writing to the slice also changes those strings. Copy the bytes if they must outlive the record.
+func (r *Reader) FieldBytes(i int) []byte {
+	if r == nil || i < 0 || i >= len(r.fieldBounds)/2 {
+		return nil
+	}
+	start := r.fieldBounds[2*i]
+	end := r.fieldBounds[2*i+1]
+	return r.dataBuf[start:end:end]
+}
+
+// SetComma validates c against the current quote character and installs it as the field
+// delimiter. It returns ErrInvalidDelimiter, leaving Comma unchanged, when c is zero, '\n',
+// '\r', or equal to the quote.
+func (r *Reader) SetComma(c byte) error {
+	quote := r.Quote
+	if quote == 0 {
+		quote = '"'
+	}
+	if c == 0 || !validDelimiters(c, quote) {
+		return ErrInvalidDelimiter
+	}
+	r.Comma = c
+	return nil
+}
+
+// SetQuote validates q against the current delimiter and installs it as the quote character.
+// It returns ErrInvalidDelimiter, leaving Quote unchanged, when q is zero, '\n', '\r', or
+// equal to the delimiter.
+func (r *Reader) SetQuote(q byte) error {
+	comma := r.Comma
+	if comma == 0 {
+		comma = ','
+	}
+	if q == 0 || !validDelimiters(comma, q) {
+		return ErrInvalidDelimiter
+	}
+	r.Quote = q
+	return nil
+}
+
+// EffectiveComma returns the delimiter byte parsing uses: CommaRune when it is set to an ASCII
+// character, otherwise Comma, defaulting to ',' when Comma is zero. It returns zero when
+// CommaRune selects a multi-byte delimiter, which no single byte can represent.
+func (r *Reader) EffectiveComma() byte {
+	return effectiveDelimiter(r.CommaRune, r.Comma, ',')
+}
+
+// EffectiveQuote returns the quote byte parsing uses, resolved like EffectiveComma from
+// QuoteRune and Quote with '"' as the default.
+func (r *Reader) EffectiveQuote() byte {
+	return effectiveDelimiter(r.QuoteRune, r.Quote, '"')
+}
+
+// effectiveDelimiter resolves a rune override, a byte setting, and its default.
+func effectiveDelimiter(override rune, b, def byte) byte {
+	switch {
+	case override >= utf8.RuneSelf:
+		return 0
+	case override != 0:
+		return byte(override)
+	case b != 0:
+		return b
+	default:
+		return def
+	}
+}
+
+// SkipRecords parses and discards the next n logical records, so quoted fields spanning several
+// lines are skipped as a whole, without building their field strings. Skipped records are not
+// checked against FieldsPerRecord. It returns io.EOF when the input ends before n records were
+// skipped, and any parse error encountered on the way.
+func (r *Reader) SkipRecords(n int) error {
+	if r == nil || r.src == nil {
+		if n > 0 {
+			return io.EOF
+		}
+		return nil
+	}
+	for ; n > 0; n-- {
+		if err := r.readRecord(); err != nil {
+			return err
 		}
 	}
+	return nil
 }
 
 // ReadAll exhausts the reader, repeatedly calling Read to collect records until io.EOF
 // and returning the accumulated records slice plus the first non-EOF error encountered.
 func (r *Reader) ReadAll() (records [][]string, err error) {
+	return r.ReadAllSized(0)
+}
+
+// ReadAllSized behaves like ReadAll but preallocates the result for capHint records, avoiding
+// repeated slice growth when the approximate record count is known. Non-positive hints allocate lazily.
+func (r *Reader) ReadAllSized(capHint int) (records [][]string, err error) {
+	return r.readAll(capHint, nil)
+}
+
+// ReadAllProgress behaves like ReadAll but calls fn with the running record count after every
+// progressInterval records, e.g. to drive a progress bar. A nil fn disables the callbacks.
+func (r *Reader) ReadAllProgress(fn func(count int64)) (records [][]string, err error) {
+	return r.readAll(0, fn)
+}
+
+// AnnotatedRecord is a record returned by ReadAllAnnotated together with its position and
+// whether it had the expected width.
+type AnnotatedRecord struct {
+	// Fields holds the record's field values.
+	Fields []string
+	// Line is the line on which the record starts.
+	Line int
+	// WidthOK reports whether the record had as many fields as FieldsPerRecord required.
+	WidthOK bool
+}
+
+// ReadAllAnnotated behaves like ReadAll but does not stop at records whose field count differs
+// from FieldsPerRecord: they are returned with WidthOK unset so callers can flag ragged rows.
+// Any other error, such as a quoting error, ends reading and is returned together with the
+// records collected so far.
+func (r *Reader) ReadAllAnnotated() ([]AnnotatedRecord, error) {
+	var records []AnnotatedRecord
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			return records, nil
+		}
+		if err != nil && !errors.Is(err, ErrorFieldCount) {
+			return records, err
+		}
+		records = append(records, AnnotatedRecord{Fields: record, Line: r.recordLine, WidthOK: err == nil})
+	}
+}
+
+// progressInterval is the number of records between ReadAllProgress callbacks.
+const progressInterval = 10000
+
+// readAll collects the remaining records, reporting progress to fn when it is non-nil.
+func (r *Reader) readAll(capHint int, fn func(count int64)) (records [][]string, err error) {
+	if capHint > 0 {
+		records = make([][]string, 0, capHint)
+	}
 	for {
 		record, err := r.Read()
 		if err == io.EOF {
@@ -313,6 +1264,84 @@ func (r *Reader) ReadAll() (records [][]string, err error) {
 			return nil, err
 		}
 		records = append(records, record)
+		if fn != nil && len(records)%progressInterval == 0 {
+			fn(int64(len(records)))
+		}
+	}
+}
+
+// ReadLine returns the next physical line without applying any CSV parsing, excluding its
+// \n, \r\n, or \r terminator, and advances the line counter. It shares the buffered source
+// with Read, so the two may be interleaved on the same stream. Lines that straddle an
+// internal buffer refill are assembled into a reader-owned slice, which is therefore only
+// valid until the next call to ReadLine. A final line without a terminator is returned
+// with a nil error, and io.EOF is reported once the stream is exhausted.
+func (r *Reader) ReadLine() ([]byte, error) {
+	if r == nil || r.src == nil || r.finished {
+		return nil, io.EOF
+	}
+	if r.ctxErr != nil {
+		return nil, r.ctxErr
+	}
+
+	r.lineBuf = r.lineBuf[:0]
+	for {
+		if r.bufPos >= r.bufLen {
+			if r.bufErr != nil {
+				err := r.bufErr
+				r.bufErr = nil
+				if err != io.EOF {
+					return nil, err
+				}
+				r.finished = true
+				if len(r.lineBuf) == 0 {
+					return nil, io.EOF
+				}
+				return r.lineBuf, nil
+			}
+
+			n, err := r.fill(r.buf)
+			if n == 0 {
+				if err != nil {
+					r.bufErr = err
+				}
+				continue
+			}
+			r.bufPos = 0
+			r.bufLen = n
+			r.bufErr = err
+		}
+
+		// Locate the closest terminator and copy everything before it.
+		data := r.buf[r.bufPos:r.bufLen]
+		next := bytes.IndexByte(data, '\n')
+		if idxCR := bytes.IndexByte(data, '\r'); idxCR >= 0 && (next < 0 || idxCR < next) {
+			next = idxCR
+		}
+		if next < 0 {
+			r.lineBuf = append(r.lineBuf, data...)
+			r.bufPos = r.bufLen
+			continue
+		}
+
+		r.lineBuf = append(r.lineBuf, data[:next]...)
+		r.bufPos += next + 1
+		if data[next] == '\r' {
+			nextByte, err := r.peekByte()
+			if err != nil && err != io.EOF {
+				return nil, err
+			}
+			if err == nil && nextByte == '\n' {
+				r.bufPos++
+				r.crlfCount++
+			} else {
+				r.crCount++
+			}
+		} else {
+			r.lfCount++
+		}
+		r.line++
+		return r.lineBuf, nil
 	}
 }
 
@@ -320,6 +1349,9 @@ func (r *Reader) ReadAll() (records [][]string, err error) {
 // and returns the materialised []string representing the current record.
 func (r *Reader) buildRecord() ([]string, error) {
 	fieldCount := len(r.fieldBounds) / 2
+	if r.InternStrings {
+		return r.internRecord(fieldCount)
+	}
 
 	var recordStr string
 	if r.ReuseRecord {
@@ -342,16 +1374,75 @@ func (r *Reader) buildRecord() ([]string, error) {
 		start := r.fieldBounds[2*i]
 		end := r.fieldBounds[2*i+1]
 		r.record[i] = recordStr[start:end]
+		if r.FieldTransform != nil {
+			r.record[i] = r.FieldTransform(i, r.record[i])
+		}
 	}
 
+	return r.record, r.checkFieldCount(fieldCount)
+}
+
+// internRecord builds the record from the canonical copies of its field values, adding values
+// not seen before to the intern table.
+func (r *Reader) internRecord(fieldCount int) ([]string, error) {
+	if r.ReuseRecord && cap(r.record) >= fieldCount {
+		r.record = r.record[:fieldCount]
+	} else {
+		r.record = make([]string, fieldCount)
+	}
+	if r.interned == nil {
+		r.interned = make(map[string]string)
+	}
+	for i := 0; i < fieldCount; i++ {
+		field := r.dataBuf[r.fieldBounds[2*i]:r.fieldBounds[2*i+1]]
+		if r.FieldTransform != nil {
+			// Intern the transformed value rather than the parsed one.
+			field = []byte(r.FieldTransform(i, string(field)))
+		}
+		s, ok := r.interned[string(field)]
+		if !ok {
+			s = string(field)
+			r.interned[s] = s
+		}
+		r.record[i] = s
+	}
+	return r.record, r.checkFieldCount(fieldCount)
+}
+
+// readRagged reads the next record like Read, but accepts any width: width capture is re-armed
+// first, so records may differ in length from those before them.
+func (r *Reader) readRagged() ([]string, error) {
+	r.FieldsPerRecord = 0
+	return r.Read()
+}
+
+// checkFieldCount enforces FieldsPerRecord for a record of n fields, capturing the width of the
+// first record when FieldsPerRecord is not positive, or taking it from the header in effect
+// under HeaderDefinesWidth, where the first record sets it until a header is in effect. A
+// mismatch is reported against the line on which the record started.
+func (r *Reader) checkFieldCount(n int) error {
+	if r.HeaderDefinesWidth {
+		switch {
+		case r.header != nil:
+			r.FieldsPerRecord = len(r.header)
+		case !r.widthTaken:
+			// The first record stands in for the header, whatever FieldsPerRecord was preset to.
+			r.FieldsPerRecord = n
+		}
+		r.widthTaken = true
+	}
 	if r.FieldsPerRecord <= 0 {
-		r.FieldsPerRecord = len(r.record)
-		return r.record, nil
+		r.FieldsPerRecord = n
+		return nil
 	}
-	if len(r.record) != r.FieldsPerRecord {
-		return r.record, ErrorFieldCount
+	if n != r.FieldsPerRecord {
+		return &ParseError{
+			Line:   r.recordLine,
+			Column: 1,
+			Err:    &FieldCountError{Expected: r.FieldsPerRecord, Got: n},
+		}
 	}
-	return r.record, nil
+	return nil
 }
 
 // wrapError attaches the current line and supplied column to err, producing a *ParseError.
@@ -359,80 +1450,312 @@ func (r *Reader) wrapError(column int, err error) error {
 	return &ParseError{Line: r.line, Column: column, Err: err}
 }
 
-// consumePlain consumes unquoted field data, updating *column, *fieldStart, and *sawQuotedField.
-// It reports whether a record terminator was seen and returns any read error encountered.
-func (r *Reader) consumePlain(column *int, fieldStart *int, sawQuotedField *bool) (bool, error) {
-	comma := r.Comma
-	if comma == 0 {
-		comma = ','
+// checkNUL returns ErrNULByte wrapped in a *ParseError when RejectNUL is set and chunk, whose
+// first byte sits at column, contains a NUL byte.
+func (r *Reader) checkNUL(chunk []byte, column int) error {
+	if !r.RejectNUL {
+		return nil
+	}
+	if i := bytes.IndexByte(chunk, 0); i >= 0 {
+		return r.wrapError(column+i, ErrNULByte)
+	}
+	return nil
+}
+
+// collapseDelimiter reports whether a delimiter should be skipped under CollapseDelimiters because
+// it directly follows another delimiter, leaving the current unquoted field empty.
+func (r *Reader) collapseDelimiter(fieldStart int, sawQuotedField bool) bool {
+	return r.CollapseDelimiters && len(r.fieldBounds) > 0 && len(r.dataBuf) == fieldStart && !sawQuotedField
+}
+
+// checkFieldSize returns ErrFieldTooLarge wrapped in a *ParseError once the field beginning at
+// fieldStart in dataBuf exceeds MaxFieldSize. The reader is marked finished so no further data is buffered.
+func (r *Reader) checkFieldSize(fieldStart, column int) error {
+	if r.MaxFieldSize <= 0 || len(r.dataBuf)-fieldStart <= r.MaxFieldSize {
+		return nil
 	}
+	r.finished = true
+	return r.wrapError(column, ErrFieldTooLarge)
+}
+
+// checkLineLength returns ErrLineTooLong wrapped in a *ParseError for the current record once n,
+// the number of its source bytes, exceeds MaxLineLength. The input is finished since the rest of
+// the record is not consumed.
+func (r *Reader) checkLineLength(n int64) error {
+	if r.MaxLineLength <= 0 || n <= int64(r.MaxLineLength) {
+		return nil
+	}
+	r.finished = true
+	return &ParseError{Line: r.recordLine, Column: 1, Err: ErrLineTooLong}
+}
 
+// consumePlain consumes unquoted field data in buf[bufPos:limit], updating *column, *fieldStart, and
+// *sawQuotedField. It reports whether a record terminator was seen and returns any read error encountered. When the
+// delimiter is multi-byte, consumption stops at its lead byte so the caller can verify the match.
+func (r *Reader) consumePlain(comma byte, limit int, column *int, fieldStart *int, sawQuotedField *bool) (bool, error) {
 	for {
-		if r.bufPos >= r.bufLen {
+		if r.bufPos >= limit {
 			return false, nil
 		}
 
-		// Locate the closest delimiter or record terminator within the buffered bytes.
-		data := r.buf[r.bufPos:r.bufLen]
-		idxComma := bytes.IndexByte(data, comma)
-		idxNewline := bytes.IndexByte(data, '\n')
-		idxCR := bytes.IndexByte(data, '\r')
-
+		// Locate the closest delimiter or record terminator within the window.
+		data := r.buf[r.bufPos:limit]
 		next := len(data)
 		delim := byte(0)
 
-		if idxComma >= 0 && idxComma < next {
-			next = idxComma
-			delim = comma
-		}
-		if idxNewline >= 0 && idxNewline < next {
-			next = idxNewline
-			delim = '\n'
-		}
-		if idxCR >= 0 && idxCR < next {
-			next = idxCR
-			delim = '\r'
+		if r.customTerminators {
+			for i, c := range data {
+				if c == comma || r.terminators[c] {
+					next, delim = i, c
+					break
+				}
+			}
+		} else {
+			idxComma := bytes.IndexByte(data, comma)
+			idxNewline := bytes.IndexByte(data, '\n')
+			idxCR := bytes.IndexByte(data, '\r')
+
+			if idxComma >= 0 && idxComma < next {
+				next = idxComma
+				delim = comma
+			}
+			if idxNewline >= 0 && idxNewline < next {
+				next = idxNewline
+				delim = '\n'
+			}
+			if idxCR >= 0 && idxCR < next {
+				next = idxCR
+				delim = '\r'
+			}
 		}
 
 		// Append the plain run preceding the delimiter and advance position counters.
 		if next > 0 {
+			if err := r.checkNUL(data[:next], *column); err != nil {
+				return false, err
+			}
 			r.dataBuf = append(r.dataBuf, data[:next]...)
 			r.bufPos += next
 			*column += next
+			if err := r.checkFieldSize(*fieldStart, *column); err != nil {
+				return false, err
+			}
 		}
 
-		if delim == 0 {
+		if delim == 0 || (delim == comma && len(r.commaRest) > 0) {
 			return false, nil
 		}
 
 		r.bufPos++
-		switch delim {
-		case comma:
-			r.fieldBounds = append(r.fieldBounds, *fieldStart, len(r.dataBuf))
-			*fieldStart = len(r.dataBuf)
-			*sawQuotedField = false
+		if delim == comma {
 			*column = *column + 1
-		case '\n':
-			r.fieldBounds = append(r.fieldBounds, *fieldStart, len(r.dataBuf))
-			*sawQuotedField = false
-			r.line++
-			*column = 1
-			return true, nil
-		case '\r':
-			// Support CRLF by peeking ahead for '\n' and consuming it together.
-			nextByte, err := r.peekByte()
-			if err == nil && nextByte == '\n' {
-				r.bufPos++
-			} else if err != nil && err != io.EOF {
-				return false, err
+			if r.collapseDelimiter(*fieldStart, *sawQuotedField) {
+				continue
 			}
 			r.fieldBounds = append(r.fieldBounds, *fieldStart, len(r.dataBuf))
+			*fieldStart = len(r.dataBuf)
 			*sawQuotedField = false
-			r.line++
-			*column = 1
-			return true, nil
+			continue
 		}
+		if err := r.consumeTerminator(delim); err != nil {
+			return false, err
+		}
+		r.fieldBounds = append(r.fieldBounds, *fieldStart, len(r.dataBuf))
+		*sawQuotedField = false
+		r.line++
+		*column = 1
+		return true, nil
+	}
+}
+
+// isTerminator reports whether c ends a record outside quotes: \n or \r by default, or any byte
+// of RecordTerminators when it is set.
+func (r *Reader) isTerminator(c byte) bool {
+	if r.customTerminators {
+		return r.terminators[c]
+	}
+	return c == '\n' || c == '\r'
+}
+
+// consumeTerminator completes the record terminator c, which has just been consumed, by also
+// consuming the \n of a \r\n pair when \n is itself a terminator, and counts it for
+// LineEndingStats.
+func (r *Reader) consumeTerminator(c byte) error {
+	r.termLen = 1
+	switch c {
+	case '\n':
+		r.lfCount++
+	case '\r':
+		// Support CRLF by peeking ahead for '\n' and consuming it together.
+		next, err := r.peekByte()
+		if err != nil && err != io.EOF {
+			return err
+		}
+		if err == nil && next == '\n' && r.isTerminator('\n') {
+			r.bufPos++
+			r.termLen = 2
+			r.crlfCount++
+		} else {
+			r.crCount++
+		}
+	}
+	return nil
+}
+
+// delimiters resolves the delimiter and quote used for parsing, preferring CommaRune and QuoteRune
+// over their byte counterparts. Multi-byte runes are reported by their UTF-8 lead byte, with the
+// continuation bytes stored in commaRest and quoteRest. It reports false for invalid combinations.
+func (r *Reader) delimiters() (comma, quote byte, ok bool) {
+	comma, quote = r.Comma, r.Quote
+	if comma == 0 {
+		comma = ','
+	}
+	if quote == 0 {
+		quote = '"'
+	}
+	r.commaRest, r.quoteRest = nil, nil
+	if r.CommaRune == 0 && r.QuoteRune == 0 {
+		r.quoteSeq = byteSeqs[quote : int(quote)+1]
+		return comma, quote, validDelimiters(comma, quote)
+	}
+
+	commaRune, quoteRune := rune(comma), rune(quote)
+	if r.CommaRune != 0 {
+		commaRune = r.CommaRune
+	}
+	if r.QuoteRune != 0 {
+		quoteRune = r.QuoteRune
+	}
+	if !validRuneDelimiter(commaRune) || !validRuneDelimiter(quoteRune) || commaRune == quoteRune {
+		return 0, 0, false
+	}
+
+	if r.delimEnc == nil {
+		r.delimEnc = make([]byte, 0, 2*utf8.UTFMax)
+	}
+	enc := utf8.AppendRune(r.delimEnc[:0], commaRune)
+	n := len(enc)
+	enc = utf8.AppendRune(enc, quoteRune)
+	comma, quote = enc[0], enc[n]
+	r.quoteSeq = enc[n:]
+	if n > 1 {
+		r.commaRest = enc[1:n:n]
+	}
+	if len(enc)-n > 1 {
+		r.quoteRest = enc[n+1:]
+	}
+	return comma, quote, true
+}
+
+// byteSeqs holds every byte value so single-byte quote sequences can be sliced from it.
+var byteSeqs = func() (seqs [256]byte) {
+	for i := range seqs {
+		seqs[i] = byte(i)
+	}
+	return seqs
+}()
+
+// quotePair resolves QuoteOpen and QuoteClose against quote, the opening quote chosen by
+// delimiters, returning the bytes that open and close a quoted field.
+func (r *Reader) quotePair(comma, quote byte) (open, closing byte, ok bool) {
+	if r.QuoteOpen == 0 && r.QuoteClose == 0 {
+		return quote, quote, true
+	}
+	if r.QuoteRune >= utf8.RuneSelf || r.QuoteOpen >= utf8.RuneSelf || r.QuoteClose >= utf8.RuneSelf {
+		return 0, 0, false
+	}
+	open, closing = quote, quote
+	if r.QuoteOpen != 0 {
+		open = r.QuoteOpen
+	}
+	if r.QuoteClose != 0 {
+		closing = r.QuoteClose
+	}
+	if !validDelimiters(comma, open) || !validDelimiters(comma, closing) {
+		return 0, 0, false
+	}
+	r.quoteSeq = byteSeqs[open : int(open)+1]
+	return open, closing, true
+}
+
+// validRuneDelimiter reports whether c may serve as a delimiter or quote rune.
+func validRuneDelimiter(c rune) bool {
+	return c != '\n' && c != '\r' && c != utf8.RuneError && utf8.ValidRune(c)
+}
+
+// validDelimiters reports whether comma and quote can be used together for parsing.
+func validDelimiters(comma, quote byte) bool {
+	return comma != quote && comma != '\n' && comma != '\r' && quote != '\n' && quote != '\r'
+}
+
+// isBlank reports whether b consists solely of spaces and tabs.
+func isBlank(b []byte) bool {
+	for _, c := range b {
+		if c != ' ' && c != '\t' {
+			return false
+		}
+	}
+	return true
+}
+
+// matchSeq reports whether the unread input starts with seq without consuming it. The buffered
+// bytes are compacted to the front of buf when seq straddles a refill boundary.
+func (r *Reader) matchSeq(seq []byte) (bool, error) {
+	for r.bufLen-r.bufPos < len(seq) {
+		avail := r.buf[r.bufPos:r.bufLen]
+		if !bytes.HasPrefix(seq, avail) {
+			return false, nil
+		}
+		if r.bufErr != nil {
+			if r.bufErr == io.EOF {
+				return false, nil
+			}
+			return false, r.bufErr
+		}
+
+		r.keepRaw(r.bufPos)
+		n := copy(r.buf, avail)
+		r.bufPos = 0
+		r.bufLen = n
+		r.rawMark = 0
+		m, err := r.fill(r.buf[n:])
+		r.bufLen += m
+		if m == 0 && err != nil && err != io.EOF {
+			return false, err
+		}
+		r.bufErr = err
+	}
+	return bytes.HasPrefix(r.buf[r.bufPos:r.bufLen], seq), nil
+}
+
+// keepRaw appends buf[rawMark:end] to rawBuf while a record is being captured, before the
+// buffered bytes are discarded or moved.
+func (r *Reader) keepRaw(end int) {
+	if r.capturing && end > r.rawMark {
+		r.rawBuf = append(r.rawBuf, r.buf[r.rawMark:end]...)
+		r.rawMark = end
+	}
+}
+
+// offset returns the stream position of the next unread byte.
+func (r *Reader) offset() int64 {
+	return r.srcBytes - int64(r.bufLen-r.bufPos)
+}
+
+// fill reads the next chunk from src into p, reporting the context error instead when an active
+// ReadContext has been cancelled.
+func (r *Reader) fill(p []byte) (int, error) {
+	if r.ctx != nil {
+		if err := r.ctx.Err(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := r.src.Read(p)
+	if n > 0 {
+		r.sawInput = true
+		r.srcBytes += int64(n)
 	}
+	return n, err
 }
 
 // peekByte returns the next buffered byte (refilling from src as needed) and propagates any read error.
@@ -445,7 +1768,8 @@ func (r *Reader) peekByte() (byte, error) {
 			return 0, r.bufErr
 		}
 
-		n, err := r.src.Read(r.buf)
+		r.keepRaw(r.bufLen)
+		n, err := r.fill(r.buf)
 		if n == 0 && err != nil {
 			return 0, err
 		}
@@ -455,5 +1779,6 @@ func (r *Reader) peekByte() (byte, error) {
 		r.bufPos = 0
 		r.bufLen = n
 		r.bufErr = err
+		r.rawMark = 0
 	}
 }
@@ -58,3 +58,97 @@ func BenchmarkEncodingCSV(b *testing.B) {
 		}
 	}
 }
+
+// repeatReader endlessly replays data so a single Reader can be benchmarked per record.
+type repeatReader struct {
+	data []byte
+	pos  int
+}
+
+func (r *repeatReader) Read(p []byte) (int, error) {
+	n := copy(p, r.data[r.pos:])
+	r.pos = (r.pos + n) % len(r.data)
+	return n, nil
+}
+
+func BenchmarkReaderReadFunc(b *testing.B) {
+	data := []byte("alpha,\"beta,gamma\",12345,delta\n")
+	cr := NewReader(&repeatReader{data: data})
+	var total int
+	visit := func(field []byte, index int) error {
+		total += len(field)
+		return nil
+	}
+
+	b.ReportAllocs()
+	b.SetBytes(int64(len(data)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := cr.ReadFunc(visit); err != nil {
+			b.Fatal(err)
+		}
+	}
+	if total == 0 {
+		b.Fatal("no fields visited")
+	}
+}
+
+func benchmarkReadAllData(rows int) []byte {
+	return []byte(strings.Repeat("alpha,beta,gamma\n", rows))
+}
+
+func BenchmarkReaderReadAll(b *testing.B) {
+	data := benchmarkReadAllData(10000)
+	b.ReportAllocs()
+	b.SetBytes(int64(len(data)))
+
+	for i := 0; i < b.N; i++ {
+		if _, err := NewReader(bytes.NewReader(data)).ReadAll(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkReaderReadAllSized(b *testing.B) {
+	data := benchmarkReadAllData(10000)
+	b.ReportAllocs()
+	b.SetBytes(int64(len(data)))
+
+	for i := 0; i < b.N; i++ {
+		if _, err := NewReader(bytes.NewReader(data)).ReadAllSized(10000); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func benchmarkWideRow(columns int) []byte {
+	return []byte(strings.TrimSuffix(strings.Repeat("abc,", columns), ",") + "\n")
+}
+
+func BenchmarkReaderWideRow(b *testing.B) {
+	data := benchmarkWideRow(5000)
+	b.ReportAllocs()
+	b.SetBytes(int64(len(data)))
+
+	for i := 0; i < b.N; i++ {
+		cr := NewReader(bytes.NewReader(data))
+		cr.ReuseRecord = true
+		if _, err := cr.Read(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkReaderWideRowWithColumns(b *testing.B) {
+	data := benchmarkWideRow(5000)
+	b.ReportAllocs()
+	b.SetBytes(int64(len(data)))
+
+	for i := 0; i < b.N; i++ {
+		cr := NewReaderWithColumns(bytes.NewReader(data), 5000)
+		cr.ReuseRecord = true
+		if _, err := cr.Read(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
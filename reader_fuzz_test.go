@@ -49,6 +49,53 @@ func FuzzReaderConsistency(f *testing.F) {
 	})
 }
 
+// roundTripDialects is the dialect corpus exercised by FuzzRoundTrip.
+var roundTripDialects = []Dialect{
+	DialectExcel,
+	DialectUnix,
+	DialectRFC4180,
+	{Comma: ';'},
+	{Comma: '\t', Quote: '\''},
+	{Comma: '|', Quote: '\''},
+}
+
+// FuzzRoundTrip checks that RoundTrip preserves arbitrary records under every dialect of the
+// corpus. The input is split into records at \x1e and into fields at \x1f.
+func FuzzRoundTrip(f *testing.F) {
+	seeds := []string{
+		"",
+		"id\x1fname\x1fnote\x1e1\x1fplain\x1f",
+		"2\x1fwith,comma\x1fhe said \"hi\"\x1e3\x1fsemi;colon\x1fmulti\nline",
+		"4\x1ftab\there\x1fapostrophe's\x1e5\x1fpipe|bar\x1fcrlf\r\ninside",
+		"\"\x1f\"\"\x1f'\x1e\x1e\x1f",
+		" padded \x1f\r\x1f\n",
+	}
+	for _, seed := range seeds {
+		for i := range roundTripDialects {
+			f.Add(seed, uint8(i))
+		}
+	}
+
+	f.Fuzz(func(t *testing.T, input string, dialect uint8) {
+		if len(input) > 1<<12 {
+			t.Skip()
+		}
+		d := roundTripDialects[int(dialect)%len(roundTripDialects)]
+
+		var records [][]string
+		for _, line := range strings.Split(input, "\x1e") {
+			records = append(records, strings.Split(line, "\x1f"))
+		}
+		got, err := RoundTrip(records, d)
+		if err != nil {
+			t.Fatalf("RoundTrip(%+v) error = %v, records=%q", d, err, records)
+		}
+		if !recordsEqual(got, records) {
+			t.Fatalf("RoundTrip(%+v) mismatch:\n got: %q\nwant: %q", d, got, records)
+		}
+	})
+}
+
 func readRecordsSequential(input string, reuse bool) ([][]string, error) {
 	r := NewReader(strings.NewReader(input))
 	r.ReuseRecord = reuse
@@ -1,11 +1,18 @@
 package swiftcsv
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	"io"
 	"reflect"
+	"slices"
+	"strconv"
 	"strings"
 	"testing"
+	"testing/iotest"
+	"unicode/utf8"
+	"unsafe"
 )
 
 func TestReaderReadRecords(t *testing.T) {
@@ -280,6 +287,30 @@ func TestReaderReadAll(t *testing.T) {
 	}
 }
 
+func TestReaderReadAllSized(t *testing.T) {
+	t.Parallel()
+
+	const input = "a,b\nc,d\ne,f\n"
+	want := [][]string{{"a", "b"}, {"c", "d"}, {"e", "f"}}
+
+	for _, hint := range []int{-1, 0, 1, 3, 100} {
+		records, err := NewReader(strings.NewReader(input)).ReadAllSized(hint)
+		if err != nil {
+			t.Fatalf("ReadAllSized(%d) error = %v", hint, err)
+		}
+		if !reflect.DeepEqual(records, want) {
+			t.Fatalf("ReadAllSized(%d) = %#v, want %#v", hint, records, want)
+		}
+		if hint >= len(want) && cap(records) != hint {
+			t.Fatalf("ReadAllSized(%d) cap = %d, want %d", hint, cap(records), hint)
+		}
+	}
+
+	if _, err := NewReader(strings.NewReader("a,\"b\n")).ReadAllSized(4); !errors.Is(err, ErrUnterminatedQuote) {
+		t.Fatalf("ReadAllSized() error = %v, want ErrUnterminatedQuote", err)
+	}
+}
+
 func TestReaderReadAllError(t *testing.T) {
 	t.Parallel()
 
@@ -366,6 +397,2130 @@ func TestReaderFieldsPerRecord(t *testing.T) {
 			t.Fatalf("Read() record length = %d, want 3", len(record))
 		}
 	})
+
+	t.Run("mismatchReportsLineAndCounts", func(t *testing.T) {
+		t.Parallel()
+
+		r := NewReader(strings.NewReader("x,y\n1,2\n\"multi\nline\",2,3\n"))
+
+		for i := 0; i < 2; i++ {
+			if _, err := r.Read(); err != nil {
+				t.Fatalf("Read() record %d error = %v, want nil", i, err)
+			}
+		}
+
+		_, err := r.Read()
+		var perr *ParseError
+		if !errors.As(err, &perr) {
+			t.Fatalf("Read() error = %v, want *ParseError", err)
+		}
+		if perr.Line != 3 {
+			t.Fatalf("ParseError.Line = %d, want 3 (record start)", perr.Line)
+		}
+		var ferr *FieldCountError
+		if !errors.As(err, &ferr) {
+			t.Fatalf("Read() error = %v, want *FieldCountError", err)
+		}
+		if ferr.Expected != 2 || ferr.Got != 3 {
+			t.Fatalf("FieldCountError = %+v, want Expected 2, Got 3", *ferr)
+		}
+		if !errors.Is(err, ErrorFieldCount) {
+			t.Fatalf("Read() error = %v, want to match ErrorFieldCount", err)
+		}
+	})
+}
+
+type countingReader struct {
+	src io.Reader
+	n   int
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.src.Read(p)
+	c.n += n
+	return n, err
+}
+
+func TestReaderMaxFieldSize(t *testing.T) {
+	t.Parallel()
+
+	t.Run("unterminatedQuotedField", func(t *testing.T) {
+		t.Parallel()
+
+		input := "id,\"" + strings.Repeat("x", 1<<20)
+		src := &countingReader{src: strings.NewReader(input)}
+		r := NewReader(src)
+		r.MaxFieldSize = 4096
+
+		_, err := r.Read()
+		var perr *ParseError
+		if !errors.As(err, &perr) {
+			t.Fatalf("Read() error = %v, want *ParseError", err)
+		}
+		if !errors.Is(err, ErrFieldTooLarge) {
+			t.Fatalf("Read() error = %v, want ErrFieldTooLarge", err)
+		}
+		if perr.Line != 1 {
+			t.Fatalf("ParseError.Line = %d, want 1", perr.Line)
+		}
+		if src.n >= len(input) {
+			t.Fatalf("reader consumed %d bytes, want buffering to stop before %d", src.n, len(input))
+		}
+		if _, err := r.Read(); !errors.Is(err, io.EOF) {
+			t.Fatalf("Read() after limit error = %v, want io.EOF", err)
+		}
+	})
+
+	t.Run("unquotedField", func(t *testing.T) {
+		t.Parallel()
+
+		r := NewReader(strings.NewReader("ok," + strings.Repeat("y", 64) + "\n"))
+		r.MaxFieldSize = 16
+
+		if _, err := r.Read(); !errors.Is(err, ErrFieldTooLarge) {
+			t.Fatalf("Read() error = %v, want ErrFieldTooLarge", err)
+		}
+	})
+
+	t.Run("fieldAtLimit", func(t *testing.T) {
+		t.Parallel()
+
+		r := NewReader(strings.NewReader("abcd,\"ef\"\"g\"\n"))
+		r.MaxFieldSize = 4
+
+		record, err := r.Read()
+		if err != nil {
+			t.Fatalf("Read() error = %v, want nil", err)
+		}
+		if want := []string{"abcd", "ef\"g"}; !reflect.DeepEqual(record, want) {
+			t.Fatalf("Read() = %#v, want %#v", record, want)
+		}
+	})
+}
+
+func TestReaderMaxLineLength(t *testing.T) {
+	t.Parallel()
+
+	t.Run("unterminatedLine", func(t *testing.T) {
+		t.Parallel()
+
+		input := "id,name\n" + strings.Repeat("x", 1<<20)
+		src := &countingReader{src: strings.NewReader(input)}
+		r := NewReader(src)
+		r.MaxLineLength = 64
+
+		if _, err := r.Read(); err != nil {
+			t.Fatalf("Read() error = %v", err)
+		}
+		_, err := r.Read()
+		var perr *ParseError
+		if !errors.As(err, &perr) || !errors.Is(err, ErrLineTooLong) {
+			t.Fatalf("Read() error = %v, want *ParseError wrapping ErrLineTooLong", err)
+		}
+		if perr.Line != 2 {
+			t.Fatalf("ParseError.Line = %d, want 2", perr.Line)
+		}
+		if src.n > 4*defaultBufferSize {
+			t.Fatalf("reader consumed %d bytes, want buffering to stop near the limit", src.n)
+		}
+		if _, err := r.Read(); !errors.Is(err, io.EOF) {
+			t.Fatalf("Read() after limit error = %v, want io.EOF", err)
+		}
+	})
+
+	tests := []struct {
+		name  string
+		input string
+		limit int
+		err   error
+	}{
+		{name: "atLimit", input: "abc,def\r\n", limit: 7},
+		{name: "atLimitNoTerminator", input: "abc,def", limit: 7},
+		{name: "overLimit", input: "abc,defg\n", limit: 7, err: ErrLineTooLong},
+		{name: "quotedLineBreak", input: "\"ab\ncd\",e\n", limit: 8, err: ErrLineTooLong},
+		{name: "quotedLineBreakAtLimit", input: "\"ab\ncd\",e\n", limit: 9},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := NewReader(strings.NewReader(tc.input))
+			r.MaxLineLength = tc.limit
+			if _, err := r.Read(); !errors.Is(err, tc.err) {
+				t.Fatalf("Read() error = %v, want %v", err, tc.err)
+			}
+		})
+	}
+}
+
+func TestReaderReadLineInterleaved(t *testing.T) {
+	t.Parallel()
+
+	long := strings.Repeat("0123456789", 250)
+	input := "# exported 2024-01-01\r\nid,name\n\"multi\nline\",x\n" + long + "\n1,2"
+
+	sources := map[string]func() io.Reader{
+		"whole":   func() io.Reader { return strings.NewReader(input) },
+		"oneByte": func() io.Reader { return iotest.OneByteReader(strings.NewReader(input)) },
+	}
+
+	for name, src := range sources {
+		src := src
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			r := NewReader(src())
+
+			line, err := r.ReadLine()
+			if err != nil || string(line) != "# exported 2024-01-01" {
+				t.Fatalf("ReadLine() = %q, %v; want metadata line", line, err)
+			}
+			rec, err := r.Read()
+			if err != nil || !reflect.DeepEqual(rec, []string{"id", "name"}) {
+				t.Fatalf("Read() = %#v, %v; want header", rec, err)
+			}
+			rec, err = r.Read()
+			if err != nil || !reflect.DeepEqual(rec, []string{"multi\nline", "x"}) {
+				t.Fatalf("Read() = %#v, %v; want quoted record", rec, err)
+			}
+			line, err = r.ReadLine()
+			if err != nil || string(line) != long {
+				t.Fatalf("ReadLine() returned %d bytes, %v; want %d bytes", len(line), err, len(long))
+			}
+			rec, err = r.Read()
+			if err != nil || !reflect.DeepEqual(rec, []string{"1", "2"}) {
+				t.Fatalf("Read() = %#v, %v; want final record", rec, err)
+			}
+			if _, err := r.ReadLine(); !errors.Is(err, io.EOF) {
+				t.Fatalf("ReadLine() error = %v, want io.EOF", err)
+			}
+		})
+	}
+}
+
+func TestReaderReadLineUnterminated(t *testing.T) {
+	t.Parallel()
+
+	r := NewReader(strings.NewReader("first\rsecond"))
+
+	for _, want := range []string{"first", "second"} {
+		line, err := r.ReadLine()
+		if err != nil || string(line) != want {
+			t.Fatalf("ReadLine() = %q, %v; want %q", line, err, want)
+		}
+	}
+	if _, err := r.ReadLine(); !errors.Is(err, io.EOF) {
+		t.Fatalf("ReadLine() error = %v, want io.EOF", err)
+	}
+	if _, err := r.Read(); !errors.Is(err, io.EOF) {
+		t.Fatalf("Read() error = %v, want io.EOF", err)
+	}
+}
+
+func TestReaderAllowLeadingBlankQuote(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+		err   error
+	}{
+		{
+			name:  "spacesBeforeQuote",
+			input: "a,  \"b,b\",c\n",
+			want:  []string{"a", "b,b", "c"},
+		},
+		{
+			name:  "tabBeforeQuote",
+			input: "\t\"x\"\"y\"\n",
+			want:  []string{"x\"y"},
+		},
+		{
+			name:  "blanksInsideQuotesKept",
+			input: " \" padded \",z\n",
+			want:  []string{" padded ", "z"},
+		},
+		{
+			name:  "textBeforeQuote",
+			input: "a, x\"b\"\n",
+			err:   ErrBareQuote,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := NewReader(strings.NewReader(tc.input))
+			r.AllowLeadingBlankQuote = true
+
+			record, err := r.Read()
+			if tc.err != nil {
+				if !errors.Is(err, tc.err) {
+					t.Fatalf("Read() error = %v, want %v", err, tc.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Read() error = %v", err)
+			}
+			if !reflect.DeepEqual(record, tc.want) {
+				t.Fatalf("Read() = %#v, want %#v", record, tc.want)
+			}
+		})
+	}
+
+	r := NewReader(strings.NewReader("a,  \"b,b\",c\n"))
+	if _, err := r.Read(); !errors.Is(err, ErrBareQuote) {
+		t.Fatalf("Read() without option error = %v, want ErrBareQuote", err)
+	}
+}
+
+func TestReaderLazyQuotes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input string
+		mode  LazyQuoteMode
+		keep  bool
+		want  []string
+		err   error
+	}{
+		{name: "literal", input: "a\"b\"c,x\n", want: []string{"a\"b\"c", "x"}},
+		{name: "literalDelimiter", input: "a\"b,c\"d\n", want: []string{"a\"b", "c\"d"}},
+		{name: "literalAfterQuoted", input: "\"a\"b\"c\n", want: []string{"ab\"c"}},
+		{name: "close", input: "a\"b\"c,x\n", mode: LazyQuoteClose, want: []string{"abc", "x"}},
+		{name: "closeDelimiter", input: "a\"b,c\"d\n", mode: LazyQuoteClose, want: []string{"ab,cd"}},
+		{name: "closeDoubled", input: "a\"b\"\"c\"d\n", mode: LazyQuoteClose, want: []string{"ab\"cd"}},
+		{name: "closeKeepQuotes", input: "a\"b,c\"d\n", mode: LazyQuoteClose, keep: true, want: []string{"a\"b,c\"d"}},
+		{name: "closeUnterminated", input: "a\"b,c\n", mode: LazyQuoteClose, err: ErrUnterminatedQuote},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := NewReader(iotest.OneByteReader(strings.NewReader(tc.input)))
+			r.LazyQuotes = true
+			r.LazyQuoteMode = tc.mode
+			r.KeepQuotes = tc.keep
+
+			record, err := r.Read()
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("Read() error = %v, want %v", err, tc.err)
+			}
+			if tc.err == nil && !reflect.DeepEqual(record, tc.want) {
+				t.Fatalf("Read() = %#v, want %#v", record, tc.want)
+			}
+		})
+	}
+
+	r := NewReader(strings.NewReader("a\"b\"c\n"))
+	r.LazyQuoteMode = LazyQuoteClose
+	if _, err := r.Read(); !errors.Is(err, ErrBareQuote) {
+		t.Fatalf("Read() without LazyQuotes error = %v, want ErrBareQuote", err)
+	}
+}
+
+func TestReaderSetDelimiters(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		setter func(*Reader, byte) error
+		value  byte
+		err    error
+	}{
+		{name: "validComma", setter: (*Reader).SetComma, value: ';'},
+		{name: "validQuote", setter: (*Reader).SetQuote, value: '\''},
+		{name: "commaIsNewline", setter: (*Reader).SetComma, value: '\n', err: ErrInvalidDelimiter},
+		{name: "commaIsCarriageReturn", setter: (*Reader).SetComma, value: '\r', err: ErrInvalidDelimiter},
+		{name: "commaIsQuote", setter: (*Reader).SetComma, value: '"', err: ErrInvalidDelimiter},
+		{name: "commaIsZero", setter: (*Reader).SetComma, value: 0, err: ErrInvalidDelimiter},
+		{name: "quoteIsNewline", setter: (*Reader).SetQuote, value: '\n', err: ErrInvalidDelimiter},
+		{name: "quoteIsCarriageReturn", setter: (*Reader).SetQuote, value: '\r', err: ErrInvalidDelimiter},
+		{name: "quoteIsComma", setter: (*Reader).SetQuote, value: ',', err: ErrInvalidDelimiter},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := NewReader(strings.NewReader(""))
+			if err := tc.setter(r, tc.value); !errors.Is(err, tc.err) {
+				t.Fatalf("setter error = %v, want %v", err, tc.err)
+			}
+			if tc.err != nil && (r.Comma != ',' || r.Quote != '"') {
+				t.Fatalf("rejected setter modified reader: comma=%q quote=%q", r.Comma, r.Quote)
+			}
+			if tc.err == nil && r.Comma != tc.value && r.Quote != tc.value {
+				t.Fatalf("setter did not install %q", tc.value)
+			}
+		})
+	}
+}
+
+func TestReaderEffectiveDelimiters(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		configure    func(*Reader)
+		comma, quote byte
+	}{
+		{name: "default", configure: func(*Reader) {}, comma: ',', quote: '"'},
+		{name: "zeroed", configure: func(r *Reader) { r.Comma, r.Quote = 0, 0 }, comma: ',', quote: '"'},
+		{name: "custom", configure: func(r *Reader) { r.Comma, r.Quote = ';', '\'' }, comma: ';', quote: '\''},
+		{name: "asciiRune", configure: func(r *Reader) { r.CommaRune = '\t' }, comma: '\t', quote: '"'},
+		{name: "multiByteRune", configure: func(r *Reader) { r.CommaRune, r.QuoteRune = '¦', '«' }, comma: 0, quote: 0},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := NewReader(strings.NewReader(""))
+			tc.configure(r)
+			if got := r.EffectiveComma(); got != tc.comma {
+				t.Fatalf("EffectiveComma() = %q, want %q", got, tc.comma)
+			}
+			if got := r.EffectiveQuote(); got != tc.quote {
+				t.Fatalf("EffectiveQuote() = %q, want %q", got, tc.quote)
+			}
+		})
+	}
+}
+
+func TestReaderReadInvalidDelimiter(t *testing.T) {
+	t.Parallel()
+
+	for _, cfg := range []struct{ comma, quote byte }{
+		{comma: '"'},
+		{comma: '\n'},
+		{quote: '\r'},
+		{comma: ';', quote: ';'},
+	} {
+		r := NewReader(strings.NewReader("a,b\n"))
+		if cfg.comma != 0 {
+			r.Comma = cfg.comma
+		}
+		if cfg.quote != 0 {
+			r.Quote = cfg.quote
+		}
+		if _, err := r.Read(); !errors.Is(err, ErrInvalidDelimiter) {
+			t.Fatalf("Read() with comma=%q quote=%q error = %v, want ErrInvalidDelimiter", cfg.comma, cfg.quote, err)
+		}
+	}
+}
+
+func TestReaderFieldBytes(t *testing.T) {
+	t.Parallel()
+
+	r := NewReader(strings.NewReader("42,\"x,y\",\n7,z,w\n"))
+	r.ReuseRecord = true
+
+	record, err := r.Read()
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	for i, field := range record {
+		if got := r.FieldBytes(i); string(got) != field {
+			t.Fatalf("FieldBytes(%d) = %q, want %q", i, got, field)
+		}
+	}
+	if got := r.FieldBytes(-1); got != nil {
+		t.Fatalf("FieldBytes(-1) = %q, want nil", got)
+	}
+	if got := r.FieldBytes(len(record)); got != nil {
+		t.Fatalf("FieldBytes(%d) = %q, want nil", len(record), got)
+	}
+
+	// The bytes alias the buffer backing the reused record strings.
+	b := r.FieldBytes(0)
+	b[0] = '9'
+	if record[0] != "92" {
+		t.Fatalf("record[0] = %q after modifying FieldBytes, want shared buffer to show %q", record[0], "92")
+	}
+
+	if _, err := r.Read(); err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if got := r.FieldBytes(2); string(got) != "w" {
+		t.Fatalf("FieldBytes(2) after second Read = %q, want %q", got, "w")
+	}
+}
+
+func TestReaderLineEndingStats(t *testing.T) {
+	t.Parallel()
+
+	r := NewReader(strings.NewReader("a,b\r\nc,\"x\r\ny\"\ne,f\rg,h\n\"q\",r\r\nlast,s"))
+
+	type counts struct{ lf, crlf, cr int }
+	want := []counts{
+		{0, 1, 0},
+		{1, 1, 0},
+		{1, 1, 1},
+		{2, 1, 1},
+		{2, 2, 1},
+		{2, 2, 1},
+	}
+	for i, w := range want {
+		if _, err := r.Read(); err != nil {
+			t.Fatalf("Read() #%d error = %v", i+1, err)
+		}
+		lf, crlf, cr := r.LineEndingStats()
+		if got := (counts{lf, crlf, cr}); got != w {
+			t.Fatalf("LineEndingStats() after record %d = %+v, want %+v", i+1, got, w)
+		}
+	}
+	if _, err := r.Read(); !errors.Is(err, io.EOF) {
+		t.Fatalf("Read() error = %v, want io.EOF", err)
+	}
+}
+
+func TestReaderRuneDelimiters(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		input     string
+		commaRune rune
+		quoteRune rune
+		want      [][]string
+	}{
+		{
+			name:      "fullwidthSemicolon",
+			input:     "名前；値\nａｂ；ｃ\n",
+			commaRune: '；',
+			want:      [][]string{{"名前", "値"}, {"ａｂ", "ｃ"}},
+		},
+		{
+			name:      "fullwidthCommaAndQuote",
+			input:     "ｘ；＂a；b＂＂c＂；z\r\n＂multi\nline＂；end；",
+			commaRune: '；',
+			quoteRune: '＂',
+			want:      [][]string{{"ｘ", "a；b＂c", "z"}, {"multi\nline", "end", ""}},
+		},
+		{
+			name:      "twoByteDelimiters",
+			input:     "a¦«b¦c«¦d\n",
+			commaRune: '¦',
+			quoteRune: '«',
+			want:      [][]string{{"a", "b¦c", "d"}},
+		},
+		{
+			name:      "asciiRune",
+			input:     "a;b;\"c;d\"\n",
+			commaRune: ';',
+			want:      [][]string{{"a", "b", "c;d"}},
+		},
+		{
+			name:      "emojiDelimiter",
+			input:     "🙂a🙂b🙂\n",
+			commaRune: '🙂',
+			want:      [][]string{{"", "a", "b", ""}},
+		},
+	}
+
+	for _, tc := range tests {
+		for _, oneByte := range []bool{false, true} {
+			tc, oneByte := tc, oneByte
+			name := tc.name
+			if oneByte {
+				name += "/oneByte"
+			}
+			t.Run(name, func(t *testing.T) {
+				t.Parallel()
+
+				var src io.Reader = strings.NewReader(tc.input)
+				if oneByte {
+					src = iotest.OneByteReader(src)
+				}
+				r := NewReader(src)
+				r.CommaRune = tc.commaRune
+				r.QuoteRune = tc.quoteRune
+
+				got, err := r.ReadAll()
+				if err != nil {
+					t.Fatalf("ReadAll() error = %v", err)
+				}
+				if !reflect.DeepEqual(got, tc.want) {
+					t.Fatalf("ReadAll() mismatch:\n got: %#v\nwant: %#v", got, tc.want)
+				}
+			})
+		}
+	}
+}
+
+func TestReaderRuneDelimitersInvalid(t *testing.T) {
+	t.Parallel()
+
+	for _, cfg := range []struct{ comma, quote rune }{
+		{comma: '；', quote: '；'},
+		{comma: '\n'},
+		{quote: '\r'},
+		{comma: utf8.MaxRune + 1},
+		{comma: '"'},
+	} {
+		r := NewReader(strings.NewReader("a\n"))
+		r.CommaRune = cfg.comma
+		r.QuoteRune = cfg.quote
+		if _, err := r.Read(); !errors.Is(err, ErrInvalidDelimiter) {
+			t.Fatalf("Read() with comma=%q quote=%q error = %v, want ErrInvalidDelimiter", cfg.comma, cfg.quote, err)
+		}
+	}
+}
+
+func TestReaderRuneQuoteBareQuote(t *testing.T) {
+	t.Parallel()
+
+	r := NewReader(strings.NewReader("ab＂c\n"))
+	r.QuoteRune = '＂'
+
+	_, err := r.Read()
+	var perr *ParseError
+	if !errors.As(err, &perr) || !errors.Is(err, ErrBareQuote) {
+		t.Fatalf("Read() error = %v, want ErrBareQuote", err)
+	}
+	if perr.Column != 3 {
+		t.Fatalf("ParseError.Column = %d, want 3", perr.Column)
+	}
+}
+
+func TestReaderCollapseDelimiters(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		input    string
+		comma    byte
+		collapse bool
+		want     [][]string
+	}{
+		{
+			name:     "spaceAligned",
+			input:    "a   b   c\nddd e   f\n",
+			comma:    ' ',
+			collapse: true,
+			want:     [][]string{{"a", "b", "c"}, {"ddd", "e", "f"}},
+		},
+		{
+			name:     "quotedSpacesPreserved",
+			input:    "\"x   y\"    \"\"   z\n",
+			comma:    ' ',
+			collapse: true,
+			want:     [][]string{{"x   y", "", "z"}},
+		},
+		{
+			name:     "commaRuns",
+			input:    "a,,,b,c\n",
+			collapse: true,
+			want:     [][]string{{"a", "b", "c"}},
+		},
+		{
+			name:     "leadingRun",
+			input:    "  a b\n",
+			comma:    ' ',
+			collapse: true,
+			want:     [][]string{{"", "a", "b"}},
+		},
+		{
+			name:  "disabled",
+			input: "a,,,b,c\n",
+			want:  [][]string{{"a", "", "", "b", "c"}},
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := NewReader(strings.NewReader(tc.input))
+			if tc.comma != 0 {
+				r.Comma = tc.comma
+			}
+			r.CollapseDelimiters = tc.collapse
+
+			got, err := r.ReadAll()
+			if err != nil {
+				t.Fatalf("ReadAll() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("ReadAll() mismatch:\n got: %#v\nwant: %#v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestReaderReadFunc(t *testing.T) {
+	t.Parallel()
+
+	const input = "a,\"b,\"\"c\"\"\",d\n\"multi\nline\",,x\nlast,row,\n"
+	want, err := NewReader(strings.NewReader(input)).ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+
+	r := NewReader(strings.NewReader(input))
+	var got [][]string
+	for {
+		var rec []string
+		err := r.ReadFunc(func(field []byte, index int) error {
+			if index != len(rec) {
+				t.Fatalf("ReadFunc() index = %d, want %d", index, len(rec))
+			}
+			rec = append(rec, string(field))
+			return nil
+		})
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("ReadFunc() error = %v", err)
+		}
+		got = append(got, rec)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("ReadFunc() records mismatch:\n got: %#v\nwant: %#v", got, want)
+	}
+}
+
+func TestReaderReadFuncErrors(t *testing.T) {
+	t.Parallel()
+
+	t.Run("callbackAborts", func(t *testing.T) {
+		t.Parallel()
+
+		exp := errors.New("stop")
+		r := NewReader(strings.NewReader("a,b,c\nd,e,f\n"))
+		var seen []int
+		err := r.ReadFunc(func(field []byte, index int) error {
+			seen = append(seen, index)
+			if index == 1 {
+				return exp
+			}
+			return nil
+		})
+		if !errors.Is(err, exp) {
+			t.Fatalf("ReadFunc() error = %v, want %v", err, exp)
+		}
+		if !reflect.DeepEqual(seen, []int{0, 1}) {
+			t.Fatalf("ReadFunc() visited %v, want [0 1]", seen)
+		}
+
+		rec, err := r.Read()
+		if err != nil || !reflect.DeepEqual(rec, []string{"d", "e", "f"}) {
+			t.Fatalf("Read() after abort = %#v, %v; want next record", rec, err)
+		}
+	})
+
+	t.Run("fieldCount", func(t *testing.T) {
+		t.Parallel()
+
+		r := NewReader(strings.NewReader("a,b\nc\n"))
+		noop := func([]byte, int) error { return nil }
+		if err := r.ReadFunc(noop); err != nil {
+			t.Fatalf("ReadFunc() error = %v", err)
+		}
+		if err := r.ReadFunc(noop); !errors.Is(err, ErrorFieldCount) {
+			t.Fatalf("ReadFunc() error = %v, want ErrorFieldCount", err)
+		}
+	})
+}
+
+func TestReaderRejectNUL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		input  string
+		column int
+		want   []string
+	}{
+		{
+			name:   "unquoted",
+			input:  "ab,c\x00d\n",
+			column: 5,
+			want:   []string{"ab", "c\x00d"},
+		},
+		{
+			name:   "quoted",
+			input:  "ab,\"x\ny\x00\"\n",
+			column: 2,
+			want:   []string{"ab", "x\ny\x00"},
+		},
+		{
+			name:   "leadingNUL",
+			input:  "\x00,z\n",
+			column: 1,
+			want:   []string{"\x00", "z"},
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := NewReader(strings.NewReader(tc.input))
+			r.RejectNUL = true
+			_, err := r.Read()
+			var perr *ParseError
+			if !errors.As(err, &perr) || !errors.Is(err, ErrNULByte) {
+				t.Fatalf("Read() error = %v, want ErrNULByte", err)
+			}
+			if perr.Column != tc.column {
+				t.Fatalf("ParseError.Column = %d, want %d", perr.Column, tc.column)
+			}
+
+			r = NewReader(strings.NewReader(tc.input))
+			got, err := r.Read()
+			if err != nil {
+				t.Fatalf("Read() with NUL passthrough error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("Read() = %#v, want %#v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestReaderNormalizeNewlines(t *testing.T) {
+	t.Parallel()
+
+	const input = "\"a\r\nb\",\"c\rd\",\"e\nf\"\r\n\"\r\r\n\",x,y\r\n"
+
+	tests := []struct {
+		name      string
+		normalize bool
+		want      [][]string
+	}{
+		{name: "verbatim", want: [][]string{{"a\r\nb", "c\rd", "e\nf"}, {"\r\r\n", "x", "y"}}},
+		{name: "normalized", normalize: true, want: [][]string{{"a\nb", "c\nd", "e\nf"}, {"\n\n", "x", "y"}}},
+	}
+
+	for _, tc := range tests {
+		for srcName, wrap := range map[string]func(io.Reader) io.Reader{
+			"buffered": func(r io.Reader) io.Reader { return r },
+			"oneByte":  iotest.OneByteReader,
+		} {
+			tc, wrap := tc, wrap
+			t.Run(tc.name+"/"+srcName, func(t *testing.T) {
+				t.Parallel()
+
+				r := NewReader(wrap(strings.NewReader(input)))
+				r.NormalizeNewlines = tc.normalize
+				got, err := r.ReadAll()
+				if err != nil {
+					t.Fatalf("ReadAll() error = %v", err)
+				}
+				if !reflect.DeepEqual(got, tc.want) {
+					t.Fatalf("ReadAll() mismatch:\n got: %#v\nwant: %#v", got, tc.want)
+				}
+				if r.line != 8 {
+					t.Fatalf("line = %d, want 8", r.line)
+				}
+			})
+		}
+	}
+}
+
+func TestReaderQuotePair(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		input       string
+		open, close byte
+		keepQuotes  bool
+		want        [][]string
+		err         error
+	}{
+		{
+			name:  "brackets",
+			input: "[a,b],c\n[x\r\ny],[say \"hi\"]\n",
+			open:  '[',
+			close: ']',
+			want:  [][]string{{"a,b", "c"}, {"x\r\ny", "say \"hi\""}},
+		},
+		{
+			name:  "closeOutsideQuotes",
+			input: "a],\"b\"\n",
+			open:  '[',
+			close: ']',
+			want:  [][]string{{"a]", "\"b\""}},
+		},
+		{
+			name:       "keepQuotes",
+			input:      "[a,b],c\n",
+			open:       '[',
+			close:      ']',
+			keepQuotes: true,
+			want:       [][]string{{"[a,b]", "c"}},
+		},
+		{
+			name:  "symmetric",
+			input: "'it''s',x\n",
+			open:  '\'',
+			close: '\'',
+			want:  [][]string{{"it's", "x"}},
+		},
+		{
+			name:  "unterminated",
+			input: "[a,b\n",
+			open:  '[',
+			close: ']',
+			err:   ErrUnterminatedQuote,
+		},
+		{
+			name:  "closeIsComma",
+			input: "[a],b\n",
+			open:  '[',
+			close: ',',
+			err:   ErrInvalidDelimiter,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := NewReader(iotest.OneByteReader(strings.NewReader(tc.input)))
+			r.QuoteOpen, r.QuoteClose = tc.open, tc.close
+			r.KeepQuotes = tc.keepQuotes
+
+			got, err := r.ReadAll()
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("ReadAll() error = %v, want %v", err, tc.err)
+			}
+			if tc.err == nil && !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("ReadAll() mismatch:\n got: %#v\nwant: %#v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestReaderKeepQuotes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		input     string
+		quote     byte
+		quoteRune rune
+		want      [][]string
+	}{
+		{
+			name:  "mixedFields",
+			input: "a,\"b,c\",\"say \"\"hi\"\"\",\"\"\n\"x\ny\",plain,\"\"\"\"\"\",\r\n",
+			want: [][]string{
+				{"a", "\"b,c\"", "\"say \"\"hi\"\"\"", "\"\""},
+				{"\"x\ny\"", "plain", "\"\"\"\"\"\"", ""},
+			},
+		},
+		{
+			name:  "customQuote",
+			input: "'it''s',x\n",
+			quote: '\'',
+			want:  [][]string{{"'it''s'", "x"}},
+		},
+		{
+			name:      "runeQuote",
+			input:     "«a««,b«,c\n",
+			quoteRune: '«',
+			want:      [][]string{{"«a««,b«", "c"}},
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := NewReader(strings.NewReader(tc.input))
+			if tc.quote != 0 {
+				r.Quote = tc.quote
+			}
+			r.QuoteRune = tc.quoteRune
+			r.KeepQuotes = true
+
+			got, err := r.ReadAll()
+			if err != nil {
+				t.Fatalf("ReadAll() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("ReadAll() mismatch:\n got: %#v\nwant: %#v", got, tc.want)
+			}
+		})
+	}
+}
+
+// chunkReader serves one chunk per Read call and runs onRead before returning chunk i.
+type chunkReader struct {
+	chunks []string
+	onRead func(i int)
+	next   int
+}
+
+func (c *chunkReader) Read(p []byte) (int, error) {
+	if c.next >= len(c.chunks) {
+		return 0, io.EOF
+	}
+	if c.onRead != nil {
+		c.onRead(c.next)
+	}
+	n := copy(p, c.chunks[c.next])
+	c.next++
+	return n, nil
+}
+
+func TestReaderReadContext(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	src := &chunkReader{chunks: []string{"a,b\n", "c,", "d,x\n", "e,f\n"}}
+	src.onRead = func(i int) {
+		if i == 1 {
+			cancel()
+		}
+	}
+	r := NewReader(src)
+
+	rec, err := r.ReadContext(ctx)
+	if err != nil || !reflect.DeepEqual(rec, []string{"a", "b"}) {
+		t.Fatalf("ReadContext() = %#v, %v; want first record", rec, err)
+	}
+	if _, err := r.ReadContext(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("ReadContext() error = %v, want context.Canceled before refill", err)
+	}
+	if _, err := r.ReadContext(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("ReadContext() on cancelled ctx error = %v, want context.Canceled", err)
+	}
+
+	// The interrupted record cannot be resumed, so the reader stays stopped.
+	if rec, err := r.ReadContext(context.Background()); !errors.Is(err, context.Canceled) {
+		t.Fatalf("ReadContext() after cancel = %#v, %v; want context.Canceled", rec, err)
+	}
+	if rec, err := r.Read(); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Read() after cancel = %#v, %v; want context.Canceled", rec, err)
+	}
+	if line, err := r.ReadLine(); !errors.Is(err, context.Canceled) {
+		t.Fatalf("ReadLine() after cancel = %q, %v; want context.Canceled", line, err)
+	}
+}
+
+func TestReaderReadContextCancelInQuotedField(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	src := &chunkReader{chunks: []string{"1,\"note with ", "\"\"quotes\"\" and, commas\"\n", "2,ok\n"}}
+	src.onRead = func(i int) {
+		if i == 0 {
+			cancel()
+		}
+	}
+	r := NewReader(src)
+	if _, err := r.ReadContext(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("ReadContext() error = %v, want context.Canceled", err)
+	}
+	for i := 0; i < 2; i++ {
+		if rec, err := r.Read(); !errors.Is(err, context.Canceled) {
+			t.Fatalf("Read() after cancel = %#v, %v; want context.Canceled", rec, err)
+		}
+	}
+}
+
+// expiringContext passes its first checks and reports context.Canceled from then on.
+type expiringContext struct {
+	context.Context
+	checks int
+}
+
+func (c *expiringContext) Err() error {
+	if c.checks--; c.checks < 0 {
+		return context.Canceled
+	}
+	return nil
+}
+
+func TestReaderReadContextCancelBetweenRecords(t *testing.T) {
+	t.Parallel()
+
+	r := NewReader(&chunkReader{chunks: []string{"a,b\n", "c,d\n"}})
+	if rec, err := r.Read(); err != nil || !reflect.DeepEqual(rec, []string{"a", "b"}) {
+		t.Fatalf("Read() = %#v, %v; want first record", rec, err)
+	}
+	// The buffer is drained, so the cancellation seen by the refill comes before the next
+	// record has started and the reader can resume.
+	ctx := &expiringContext{Context: context.Background(), checks: 1}
+	if _, err := r.ReadContext(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("ReadContext() error = %v, want context.Canceled", err)
+	}
+	if rec, err := r.ReadContext(context.Background()); err != nil || !reflect.DeepEqual(rec, []string{"c", "d"}) {
+		t.Fatalf("ReadContext() after cancel = %#v, %v; want second record", rec, err)
+	}
+}
+
+func TestReaderSkipLines(t *testing.T) {
+	t.Parallel()
+
+	const input = "Report \"Q3\" generated, by \"ops\nexported: 2024-01-01\r\nid,name\n1,ba\"d\n"
+
+	r := NewReader(strings.NewReader(input))
+	r.SkipLines = 2
+
+	header, err := r.Read()
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if want := []string{"id", "name"}; !reflect.DeepEqual(header, want) {
+		t.Fatalf("Read() header = %#v, want %#v", header, want)
+	}
+
+	_, err = r.Read()
+	var perr *ParseError
+	if !errors.As(err, &perr) {
+		t.Fatalf("Read() error = %v, want *ParseError", err)
+	}
+	if perr.Line != 4 {
+		t.Fatalf("ParseError.Line = %d, want 4 counting skipped lines", perr.Line)
+	}
+}
+
+func TestReaderSkipLinesPastEOF(t *testing.T) {
+	t.Parallel()
+
+	r := NewReader(strings.NewReader("only line\n"))
+	r.SkipLines = 3
+
+	if _, err := r.Read(); !errors.Is(err, io.EOF) {
+		t.Fatalf("Read() error = %v, want io.EOF", err)
+	}
+}
+
+func TestReaderDedupeConsecutive(t *testing.T) {
+	t.Parallel()
+
+	const input = "t,20\nt,20\nt,20\nt,21\nab,c\na,bc\na,bc\n\"t\",20\nt,20\nt,21\n"
+	want := [][]string{
+		{"t", "20"},
+		{"t", "21"},
+		{"ab", "c"},
+		{"a", "bc"},
+		{"t", "20"},
+		{"t", "21"},
+	}
+
+	for name, reuse := range map[string]bool{"copy": false, "reuseRecord": true} {
+		reuse := reuse
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			r := NewReader(strings.NewReader(input))
+			r.DedupeConsecutive = true
+			r.ReuseRecord = reuse
+
+			var got [][]string
+			for {
+				rec, err := r.Read()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					t.Fatalf("Read() error = %v", err)
+				}
+				got = append(got, cloneStrings(rec))
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("records = %#v, want %#v", got, want)
+			}
+		})
+	}
+}
+
+func TestReaderEmpty(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		input   string
+		records int
+		want    bool
+	}{
+		{name: "empty", input: "", records: 0, want: true},
+		{name: "whitespaceOnly", input: " \n", records: 1, want: false},
+		{name: "newlineOnly", input: "\n", records: 1, want: false},
+		{name: "records", input: "a,b\nc,d\n", records: 2, want: false},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := NewReader(strings.NewReader(tc.input))
+			if r.Empty() {
+				t.Fatalf("Empty() = true before any Read")
+			}
+			records, err := r.ReadAll()
+			if err != nil {
+				t.Fatalf("ReadAll() error = %v", err)
+			}
+			if len(records) != tc.records {
+				t.Fatalf("ReadAll() returned %d records, want %d", len(records), tc.records)
+			}
+			if got := r.Empty(); got != tc.want {
+				t.Fatalf("Empty() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestReaderComments(t *testing.T) {
+	t.Parallel()
+
+	const input = "# source: sensors\r\n#unit=C\nid,temp\n1,#20\n# mid\n\"#2\",21\n#tail"
+
+	r := NewReader(iotest.OneByteReader(strings.NewReader(input)))
+	r.Comment = '#'
+
+	records, err := r.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+	wantRecords := [][]string{{"id", "temp"}, {"1", "#20"}, {"#2", "21"}}
+	if !reflect.DeepEqual(records, wantRecords) {
+		t.Fatalf("ReadAll() = %#v, want %#v", records, wantRecords)
+	}
+	wantComments := []string{" source: sensors", "unit=C", " mid", "tail"}
+	if got := r.Comments(); !reflect.DeepEqual(got, wantComments) {
+		t.Fatalf("Comments() = %#v, want %#v", got, wantComments)
+	}
+}
+
+func TestReaderCommentsDisabled(t *testing.T) {
+	t.Parallel()
+
+	r := NewReader(strings.NewReader("#a,b\n"))
+	records, err := r.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+	if want := [][]string{{"#a", "b"}}; !reflect.DeepEqual(records, want) {
+		t.Fatalf("ReadAll() = %#v, want %#v", records, want)
+	}
+	if got := r.Comments(); got != nil {
+		t.Fatalf("Comments() = %#v, want nil", got)
+	}
+
+	r = NewReader(strings.NewReader("a,b\n"))
+	r.Comment = ','
+	if _, err := r.Read(); !errors.Is(err, ErrInvalidDelimiter) {
+		t.Fatalf("Read() error = %v, want ErrInvalidDelimiter", err)
+	}
+}
+
+func TestReaderDecoder(t *testing.T) {
+	t.Parallel()
+
+	latin1 := func(b []byte) []byte {
+		out := make([]byte, 0, 2*len(b))
+		for _, c := range b {
+			out = utf8.AppendRune(out, rune(c))
+		}
+		return out
+	}
+	const input = "caf\xe9,na\xefve\n\"\xc5ngstr\xf6m, \xb5\",x\n"
+
+	r := NewReader(strings.NewReader(input))
+	r.Decoder = latin1
+	records, err := r.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+	want := [][]string{{"café", "naïve"}, {"Ångström, µ", "x"}}
+	if !reflect.DeepEqual(records, want) {
+		t.Fatalf("ReadAll() = %#v, want %#v", records, want)
+	}
+
+	r = NewReader(strings.NewReader(input))
+	r.Decoder = latin1
+	var fields []string
+	if err := r.ReadFunc(func(field []byte, _ int) error {
+		fields = append(fields, string(field))
+		return nil
+	}); err != nil {
+		t.Fatalf("ReadFunc() error = %v", err)
+	}
+	if !reflect.DeepEqual(fields, want[0]) {
+		t.Fatalf("ReadFunc() fields = %#v, want %#v", fields, want[0])
+	}
+}
+
+func TestReaderFieldTransform(t *testing.T) {
+	t.Parallel()
+
+	const input = "id,name\n1,émile\n2,\"Bo, jr\"\n2,Bo\n"
+	want := [][]string{{"id", "NAME"}, {"1", "ÉMILE"}, {"2", "BO, JR"}, {"2", "BO"}}
+	upper := func(index int, value string) string {
+		if index == 0 {
+			return value
+		}
+		return strings.ToUpper(value)
+	}
+
+	for name, configure := range map[string]func(r *Reader){
+		"default":     func(r *Reader) {},
+		"reuseRecord": func(r *Reader) { r.ReuseRecord = true },
+		"intern":      func(r *Reader) { r.InternStrings = true },
+	} {
+		configure := configure
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			r := NewReader(strings.NewReader(input))
+			r.FieldTransform = upper
+			configure(r)
+			var got [][]string
+			for {
+				record, err := r.Read()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					t.Fatalf("Read() error = %v", err)
+				}
+				got = append(got, cloneRecord(record))
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("Read() = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestReaderFieldTransformHelpers(t *testing.T) {
+	t.Parallel()
+
+	// Strips a spreadsheet formula guard and folds case.
+	unguard := func(_ int, value string) string {
+		return strings.ToLower(strings.TrimPrefix(value, "'"))
+	}
+	newReader := func(input string) *Reader {
+		r := NewReader(strings.NewReader(input))
+		r.FieldTransform = unguard
+		return r
+	}
+
+	seq, err := newReader("'A,B\n").ReadFields()
+	if err != nil {
+		t.Fatalf("ReadFields() error = %v", err)
+	}
+	var fields []string
+	for _, value := range seq {
+		fields = append(fields, value)
+	}
+	if want := []string{"a", "b"}; !reflect.DeepEqual(fields, want) {
+		t.Fatalf("ReadFields() = %q, want %q", fields, want)
+	}
+
+	counts, err := newReader("K\nk\n'q\n").GroupCount(0)
+	if err != nil {
+		t.Fatalf("GroupCount() error = %v", err)
+	}
+	if want := map[string]int64{"k": 2, "q": 1}; !reflect.DeepEqual(counts, want) {
+		t.Fatalf("GroupCount() = %v, want %v", counts, want)
+	}
+
+	findings, err := newReader("'=SUM(A1),x\n").ScanForFormulas()
+	if err != nil {
+		t.Fatalf("ScanForFormulas() error = %v", err)
+	}
+	if want := []Finding{{Line: 1, Column: 1, Value: "=sum(a1)"}}; !reflect.DeepEqual(findings, want) {
+		t.Fatalf("ScanForFormulas() = %+v, want %+v", findings, want)
+	}
+
+	entries, err := Diff(newReader("A,1\nb,2\n"), newReader("a,1\nB,3\n"))
+	if err != nil {
+		t.Fatalf("Diff() error = %v", err)
+	}
+	if want := []DiffEntry{{LineA: 2, LineB: 2, A: []string{"b", "2"}, B: []string{"b", "3"}}}; !reflect.DeepEqual(entries, want) {
+		t.Fatalf("Diff() = %+v, want %+v", entries, want)
+	}
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	if err := MergeJoin(newReader("K,1\n"), newReader("k,2\n"), 0, 0, w); err != nil {
+		t.Fatalf("MergeJoin() error = %v", err)
+	}
+	w.Flush()
+	if got, want := buf.String(), "k,1,k,2\n"; got != want {
+		t.Fatalf("MergeJoin() wrote %q, want %q", got, want)
+	}
+}
+
+func TestNewReaderWithColumns(t *testing.T) {
+	t.Parallel()
+
+	r := NewReaderWithColumns(strings.NewReader("a,b,c\n1,2,3\n"), 3000)
+	if cap(r.fieldBounds) < 6000 || cap(r.record) < 3000 {
+		t.Fatalf("capacities = %d, %d; want at least 6000, 3000", cap(r.fieldBounds), cap(r.record))
+	}
+	records, err := r.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+	if want := [][]string{{"a", "b", "c"}, {"1", "2", "3"}}; !reflect.DeepEqual(records, want) {
+		t.Fatalf("ReadAll() = %#v, want %#v", records, want)
+	}
+}
+
+func TestReaderReadWithOffset(t *testing.T) {
+	t.Parallel()
+
+	input := "# meta\nid,note\r\n1,\"multi\nline\"\n2," + strings.Repeat("x", 3*defaultBufferSize) + "\r3,\"q\"\"\",\n4,last"
+
+	for name, wrap := range map[string]func(io.Reader) io.Reader{
+		"buffered": func(r io.Reader) io.Reader { return r },
+		"oneByte":  iotest.OneByteReader,
+	} {
+		wrap := wrap
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			r := NewReader(wrap(strings.NewReader(input)))
+			r.Comment = '#'
+
+			type span struct{ start, end int64 }
+			var spans []span
+			var records [][]string
+			for {
+				rec, start, end, err := r.ReadWithOffset()
+				if err == io.EOF {
+					break
+				}
+				if err != nil && !errors.Is(err, ErrorFieldCount) {
+					t.Fatalf("ReadWithOffset() error = %v", err)
+				}
+				spans = append(spans, span{start, end})
+				records = append(records, cloneStrings(rec))
+			}
+			if len(records) != 5 {
+				t.Fatalf("read %d records, want 5", len(records))
+			}
+			if spans[0].start != int64(len("# meta\n")) || spans[len(spans)-1].end != int64(len(input)) {
+				t.Fatalf("spans = %v, want first start %d and last end %d", spans, len("# meta\n"), len(input))
+			}
+
+			src := strings.NewReader(input)
+			for i, sp := range spans {
+				if _, err := src.Seek(sp.start, io.SeekStart); err != nil {
+					t.Fatalf("Seek() error = %v", err)
+				}
+				raw := make([]byte, sp.end-sp.start)
+				if _, err := io.ReadFull(src, raw); err != nil {
+					t.Fatalf("ReadFull() error = %v", err)
+				}
+				got, err := NewReader(bytes.NewReader(raw)).Read()
+				if err != nil {
+					t.Fatalf("record %d from %q: Read() error = %v", i, raw, err)
+				}
+				if !reflect.DeepEqual(got, records[i]) {
+					t.Fatalf("record %d reconstructed as %#v, want %#v", i, got, records[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParseRecord(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		line         string
+		comma, quote byte
+		want         []string
+	}{
+		{name: "plain", line: "a,b,c", want: []string{"a", "b", "c"}},
+		{name: "trailingNewline", line: "a,b\n", want: []string{"a", "b"}},
+		{name: "trailingCRLF", line: "a,b\r\n", want: []string{"a", "b"}},
+		{name: "quoted", line: `1,"x, y",z`, want: []string{"1", "x, y", "z"}},
+		{name: "escapedQuotes", line: `"say ""hi""",""`, want: []string{`say "hi"`, ""}},
+		{name: "quotedLineBreak", line: "\"multi\nline\",x", want: []string{"multi\nline", "x"}},
+		{name: "customDelimiters", line: "'a;b';c", comma: ';', quote: '\'', want: []string{"a;b", "c"}},
+		{name: "empty", line: "", want: []string{""}},
+		{name: "emptyFields", line: ",", want: []string{"", ""}},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParseRecord([]byte(tc.line), tc.comma, tc.quote)
+			if err != nil {
+				t.Fatalf("ParseRecord(%q) error = %v", tc.line, err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("ParseRecord(%q) = %q, want %q", tc.line, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseRecordErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		line   string
+		column int
+		want   error
+	}{
+		{name: "bareQuote", line: `ab"c,d`, column: 3, want: ErrBareQuote},
+		{name: "unterminated", line: `a,"bc`, column: 6, want: ErrUnterminatedQuote},
+		{name: "multipleRecords", line: "a,b\nc,d", column: 1, want: ErrMultipleRecords},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParseRecord([]byte(tc.line), 0, 0)
+			var perr *ParseError
+			if !errors.As(err, &perr) || !errors.Is(err, tc.want) {
+				t.Fatalf("ParseRecord(%q) error = %v, want *ParseError wrapping %v", tc.line, err, tc.want)
+			}
+			if perr.Column != tc.column || got != nil {
+				t.Fatalf("ParseRecord(%q) = %q, column %d, want nil, column %d", tc.line, got, perr.Column, tc.column)
+			}
+		})
+	}
+
+	if _, err := ParseRecord([]byte("a,b"), ',', ','); !errors.Is(err, ErrInvalidDelimiter) {
+		t.Fatalf("ParseRecord() error = %v, want %v", err, ErrInvalidDelimiter)
+	}
+}
+
+func TestNewReaderAt(t *testing.T) {
+	t.Parallel()
+
+	var input strings.Builder
+	input.WriteString("id,note\r\n")
+	for i := 0; i < 500; i++ {
+		input.WriteString(strconv.Itoa(i) + ",\"multi\nline " + strconv.Itoa(i) + "\"\n")
+	}
+	src := strings.NewReader(input.String())
+
+	type span struct{ start, end int64 }
+	spans := make(map[int]span)
+	r := NewReader(src)
+	for i := 0; ; i++ {
+		_, start, end, err := r.ReadWithOffset()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("ReadWithOffset() error = %v", err)
+		}
+		spans[i] = span{start, end}
+	}
+
+	for _, i := range []int{0, 1, 250, 500} {
+		sp := spans[i]
+		rec, err := NewReaderAt(src, sp.start, sp.end-sp.start).ReadAll()
+		if err != nil {
+			t.Fatalf("record %d: ReadAll() error = %v", i, err)
+		}
+		want := [][]string{{"id", "note"}}
+		if i > 0 {
+			want = [][]string{{strconv.Itoa(i - 1), "multi\nline " + strconv.Itoa(i-1)}}
+		}
+		if !reflect.DeepEqual(rec, want) {
+			t.Fatalf("record %d: ReadAll() = %#v, want %#v", i, rec, want)
+		}
+	}
+
+	sp := spans[10]
+	got, err := NewReaderAt(src, sp.start, spans[12].end-sp.start).ReadAll()
+	if err != nil || len(got) != 3 || got[0][0] != "9" || got[2][0] != "11" {
+		t.Fatalf("multi-record span ReadAll() = %#v, %v; want records 9 to 11", got, err)
+	}
+}
+
+func TestReaderLastRecordRaw(t *testing.T) {
+	t.Parallel()
+
+	long := "2," + strings.Repeat("x", 3*defaultBufferSize) + "\r"
+	raws := []string{"id,note\r\n", "1,\"multi\nline\"\n", long, "3,\"q\"\"\",\n", "4,last"}
+	input := "# meta\n" + strings.Join(raws, "")
+
+	for name, wrap := range map[string]func(io.Reader) io.Reader{
+		"buffered": func(r io.Reader) io.Reader { return r },
+		"oneByte":  iotest.OneByteReader,
+	} {
+		wrap := wrap
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			r := NewReader(wrap(strings.NewReader(input)))
+			r.Comment = '#'
+			r.CaptureRaw = true
+			if got := r.LastRecordRaw(); len(got) != 0 {
+				t.Fatalf("LastRecordRaw() before Read = %q, want empty", got)
+			}
+			for i, want := range raws {
+				if _, err := r.Read(); err != nil && !errors.Is(err, ErrorFieldCount) {
+					t.Fatalf("record %d: Read() error = %v", i, err)
+				}
+				if got := string(r.LastRecordRaw()); got != want {
+					t.Fatalf("record %d: LastRecordRaw() = %.40q, want %.40q", i, got, want)
+				}
+			}
+			if _, err := r.Read(); err != io.EOF {
+				t.Fatalf("Read() error = %v, want io.EOF", err)
+			}
+		})
+	}
+}
+
+func TestReaderLastRecordRawDisabled(t *testing.T) {
+	t.Parallel()
+
+	r := NewReader(strings.NewReader("a,b\n"))
+	if _, err := r.Read(); err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if got := r.LastRecordRaw(); got != nil {
+		t.Fatalf("LastRecordRaw() without CaptureRaw = %q, want nil", got)
+	}
+}
+
+func TestReaderRecordTerminators(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		terminators string
+		input       string
+		want        [][]string
+		lines       []int
+	}{
+		{
+			name:        "record separator",
+			terminators: "\x1e",
+			input:       "a,b\nc\x1e1,\"x\x1ey\"\x1e2,z",
+			want:        [][]string{{"a", "b\nc"}, {"1", "x\x1ey"}, {"2", "z"}},
+			lines:       []int{1, 2, 3},
+		},
+		{
+			name:        "mixed",
+			terminators: "\n\r\x1e\f",
+			input:       "a,b\r\nc,d\x1ee,f\fg,h\ri,j\n",
+			want:        [][]string{{"a", "b"}, {"c", "d"}, {"e", "f"}, {"g", "h"}, {"i", "j"}},
+			lines:       []int{1, 2, 3, 4, 5},
+		},
+		{
+			name:        "cr without lf",
+			terminators: "\r",
+			input:       "a,b\r\nc,d\r",
+			want:        [][]string{{"a", "b"}, {"\nc", "d"}},
+			lines:       []int{1, 2},
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		for name, wrap := range map[string]func(io.Reader) io.Reader{
+			"buffered": func(r io.Reader) io.Reader { return r },
+			"oneByte":  iotest.OneByteReader,
+		} {
+			wrap := wrap
+			t.Run(tc.name+"/"+name, func(t *testing.T) {
+				t.Parallel()
+
+				r := NewReader(wrap(strings.NewReader(tc.input)))
+				r.RecordTerminators = []byte(tc.terminators)
+				for i, want := range tc.want {
+					got, err := r.Read()
+					if err != nil {
+						t.Fatalf("record %d: Read() error = %v", i, err)
+					}
+					if !reflect.DeepEqual(got, want) {
+						t.Fatalf("record %d: Read() = %q, want %q", i, got, want)
+					}
+					if r.recordLine != tc.lines[i] {
+						t.Fatalf("record %d: line = %d, want %d", i, r.recordLine, tc.lines[i])
+					}
+				}
+				if _, err := r.Read(); err != io.EOF {
+					t.Fatalf("Read() error = %v, want io.EOF", err)
+				}
+			})
+		}
+	}
+}
+
+func TestReaderRecordTerminatorsInvalid(t *testing.T) {
+	t.Parallel()
+
+	r := NewReader(strings.NewReader("a;b\n"))
+	r.Comma = ';'
+	r.RecordTerminators = []byte{'\n', ';'}
+	if _, err := r.Read(); !errors.Is(err, ErrInvalidDelimiter) {
+		t.Fatalf("Read() error = %v, want %v", err, ErrInvalidDelimiter)
+	}
+}
+
+func TestReaderMaxRecords(t *testing.T) {
+	t.Parallel()
+
+	const input = "a,1\nb,2\nc,3\nd,4\n"
+
+	tests := []struct {
+		name  string
+		limit int
+		want  int
+	}{
+		{name: "unlimited", limit: 0, want: 4},
+		{name: "limited", limit: 2, want: 2},
+		{name: "limitAboveCount", limit: 10, want: 4},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := NewReader(strings.NewReader(input))
+			r.MaxRecords = tc.limit
+			records, err := r.ReadAll()
+			if err != nil {
+				t.Fatalf("ReadAll() error = %v", err)
+			}
+			if len(records) != tc.want {
+				t.Fatalf("ReadAll() returned %d records, want %d", len(records), tc.want)
+			}
+			if _, err := r.Read(); !errors.Is(err, io.EOF) {
+				t.Fatalf("Read() after limit error = %v, want io.EOF", err)
+			}
+		})
+	}
+
+	t.Run("read", func(t *testing.T) {
+		t.Parallel()
+
+		r := NewReader(strings.NewReader(input))
+		r.MaxRecords = 1
+		if rec, err := r.Read(); err != nil || rec[0] != "a" {
+			t.Fatalf("Read() = %v, %v; want [a 1], nil", rec, err)
+		}
+		if err := r.ReadFunc(func([]byte, int) error { return nil }); !errors.Is(err, io.EOF) {
+			t.Fatalf("ReadFunc() error = %v, want io.EOF", err)
+		}
+	})
+}
+
+func TestReaderFieldQuoted(t *testing.T) {
+	t.Parallel()
+
+	r := NewReader(strings.NewReader("\"a\",b,\"\",\"c,d\"\nplain,\"q\"\"x\",,\"\"\n"))
+	r.ReuseRecord = true
+
+	tests := [][]bool{
+		{true, false, true, true},
+		{false, true, false, true},
+	}
+	for n, want := range tests {
+		if _, err := r.Read(); err != nil {
+			t.Fatalf("Read() record %d error = %v", n, err)
+		}
+		for i, q := range want {
+			if got := r.FieldQuoted(i); got != q {
+				t.Fatalf("record %d: FieldQuoted(%d) = %v, want %v", n, i, got, q)
+			}
+		}
+		if r.FieldQuoted(-1) || r.FieldQuoted(len(want)) {
+			t.Fatalf("record %d: FieldQuoted out of range = true, want false", n)
+		}
+	}
+}
+
+func TestReaderLineBreakMatrix(t *testing.T) {
+	t.Parallel()
+
+	// Outside quotes \n, \r\n, and a lone \r each end a record, including a lone \r at EOF.
+	// Inside quotes all three are kept verbatim as field data and advance the line counter once.
+	tests := []struct {
+		name   string
+		chunks []string
+		want   [][]string
+		lines  int
+	}{
+		{name: "lfOutside", chunks: []string{"a\nb\n"}, want: [][]string{{"a"}, {"b"}}, lines: 3},
+		{name: "crlfOutside", chunks: []string{"a\r\nb\r\n"}, want: [][]string{{"a"}, {"b"}}, lines: 3},
+		{name: "crOutside", chunks: []string{"a\rb\r"}, want: [][]string{{"a"}, {"b"}}, lines: 3},
+		{name: "crAtEOF", chunks: []string{"a,\r"}, want: [][]string{{"a", ""}}, lines: 2},
+		{name: "crThenCRLF", chunks: []string{"a\r\r\nb"}, want: [][]string{{"a"}, {""}, {"b"}}, lines: 3},
+		{name: "crAfterQuotedField", chunks: []string{"\"a\"\r\"b\"\r"}, want: [][]string{{"a"}, {"b"}}, lines: 3},
+		{name: "crBeforeQuotedField", chunks: []string{"a\r\"b\"\n", "c\n"}, want: [][]string{{"a"}, {"b"}, {"c"}}, lines: 4},
+		{name: "crlfSplitAcrossReads", chunks: []string{"a\r", "\nb\r", "\n"}, want: [][]string{{"a"}, {"b"}}, lines: 3},
+		{name: "lfInside", chunks: []string{"\"x\ny\"\n"}, want: [][]string{{"x\ny"}}, lines: 3},
+		{name: "crlfInside", chunks: []string{"\"x\r\ny\"\n"}, want: [][]string{{"x\r\ny"}}, lines: 3},
+		{name: "crInside", chunks: []string{"\"x\ry\"\n"}, want: [][]string{{"x\ry"}}, lines: 3},
+		{name: "crInsideAtQuoteEnd", chunks: []string{"\"x\r\"\n"}, want: [][]string{{"x\r"}}, lines: 3},
+		{name: "crlfInsideSplitAcrossReads", chunks: []string{"\"x\r", "\ny\"\n"}, want: [][]string{{"x\r\ny"}}, lines: 3},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			for _, oneByte := range []bool{false, true} {
+				var src io.Reader = &chunkReader{chunks: tc.chunks}
+				if oneByte {
+					src = iotest.OneByteReader(strings.NewReader(strings.Join(tc.chunks, "")))
+				}
+				r := NewReader(src)
+				var got [][]string
+				for {
+					// Re-arm width capture so records may differ in width.
+					r.FieldsPerRecord = 0
+					record, err := r.Read()
+					if err == io.EOF {
+						break
+					}
+					if err != nil {
+						t.Fatalf("Read() error = %v", err)
+					}
+					got = append(got, record)
+				}
+				if !reflect.DeepEqual(got, tc.want) {
+					t.Fatalf("oneByte=%v: records = %q, want %q", oneByte, got, tc.want)
+				}
+				if r.line != tc.lines {
+					t.Fatalf("oneByte=%v: line = %d, want %d", oneByte, r.line, tc.lines)
+				}
+			}
+		})
+	}
+}
+
+func TestReaderReadFields(t *testing.T) {
+	t.Parallel()
+
+	r := NewReader(strings.NewReader("id,\"multi\nline\",c,d\n1,2,3,4\n5,6,7,8\n"))
+
+	fields, err := r.ReadFields()
+	if err != nil {
+		t.Fatalf("ReadFields() error = %v", err)
+	}
+	var got []string
+	for i, field := range fields {
+		if i == 2 {
+			break
+		}
+		got = append(got, field)
+	}
+	if want := []string{"id", "multi\nline"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("early break fields = %#v, want %#v", got, want)
+	}
+
+	fields, err = r.ReadFields()
+	if err != nil {
+		t.Fatalf("ReadFields() error = %v", err)
+	}
+	got = got[:0]
+	for i, field := range fields {
+		if i != len(got) {
+			t.Fatalf("index = %d, want %d", i, len(got))
+		}
+		got = append(got, field)
+	}
+	if want := []string{"1", "2", "3", "4"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("full fields = %#v, want %#v", got, want)
+	}
+
+	record, err := r.Read()
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if want := []string{"5", "6", "7", "8"}; !reflect.DeepEqual(record, want) {
+		t.Fatalf("Read() after ReadFields = %#v, want %#v", record, want)
+	}
+	if _, err := r.ReadFields(); !errors.Is(err, io.EOF) {
+		t.Fatalf("ReadFields() error = %v, want io.EOF", err)
+	}
+}
+
+func TestReaderEOFOnBlankLine(t *testing.T) {
+	t.Parallel()
+
+	const input = "id,name\n1,\"two\n\nlines\"\n\n  \r\n\t\nsum,total\n3,4\n\n"
+
+	r := NewReader(strings.NewReader(input))
+	r.EOFOnBlankLine = true
+
+	readSection := func() [][]string {
+		t.Helper()
+		var out [][]string
+		for {
+			record, err := r.Read()
+			if err == io.EOF {
+				return out
+			}
+			if err != nil {
+				t.Fatalf("Read() error = %v", err)
+			}
+			out = append(out, record)
+		}
+	}
+
+	first := readSection()
+	if want := [][]string{{"id", "name"}, {"1", "two\n\nlines"}}; !reflect.DeepEqual(first, want) {
+		t.Fatalf("first section = %#v, want %#v", first, want)
+	}
+	second := readSection()
+	if want := [][]string{{"sum", "total"}, {"3", "4"}}; !reflect.DeepEqual(second, want) {
+		t.Fatalf("second section = %#v, want %#v", second, want)
+	}
+	if rest := readSection(); rest != nil {
+		t.Fatalf("read past final section = %#v, want nothing", rest)
+	}
+}
+
+func TestReaderReadAllProgress(t *testing.T) {
+	t.Parallel()
+
+	const rows = 2*progressInterval + 500
+	input := strings.Repeat("a,b\n", rows)
+
+	var calls []int64
+	records, err := NewReader(strings.NewReader(input)).ReadAllProgress(func(count int64) {
+		calls = append(calls, count)
+	})
+	if err != nil {
+		t.Fatalf("ReadAllProgress() error = %v", err)
+	}
+	if len(records) != rows {
+		t.Fatalf("ReadAllProgress() returned %d records, want %d", len(records), rows)
+	}
+	if want := []int64{progressInterval, 2 * progressInterval}; !reflect.DeepEqual(calls, want) {
+		t.Fatalf("progress calls = %v, want %v", calls, want)
+	}
+
+	records, err = NewReader(strings.NewReader(input)).ReadAllProgress(nil)
+	if err != nil || len(records) != rows {
+		t.Fatalf("ReadAllProgress(nil) = %d records, %v; want %d, nil", len(records), err, rows)
+	}
+}
+
+func TestReaderSkipRecords(t *testing.T) {
+	t.Parallel()
+
+	var input strings.Builder
+	for i := 0; i < 2000; i++ {
+		input.WriteString(strconv.Itoa(i) + ",\"line\n" + strconv.Itoa(i) + "\"\r\n")
+	}
+
+	for name, wrap := range map[string]func(io.Reader) io.Reader{
+		"buffered": func(r io.Reader) io.Reader { return r },
+		"oneByte":  iotest.OneByteReader,
+	} {
+		wrap := wrap
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			r := NewReader(wrap(strings.NewReader(input.String())))
+			if err := r.SkipRecords(0); err != nil {
+				t.Fatalf("SkipRecords(0) error = %v", err)
+			}
+			if err := r.SkipRecords(1000); err != nil {
+				t.Fatalf("SkipRecords() error = %v", err)
+			}
+			record, err := r.Read()
+			if err != nil {
+				t.Fatalf("Read() error = %v", err)
+			}
+			if want := []string{"1000", "line\n1000"}; !reflect.DeepEqual(record, want) {
+				t.Fatalf("Read() after SkipRecords = %#v, want %#v", record, want)
+			}
+			if r.recordLine != 2001 {
+				t.Fatalf("record line = %d, want 2001", r.recordLine)
+			}
+			if err := r.SkipRecords(1000); err != io.EOF {
+				t.Fatalf("SkipRecords() past end error = %v, want io.EOF", err)
+			}
+		})
+	}
+}
+
+func TestReaderSkipRecordsError(t *testing.T) {
+	t.Parallel()
+
+	r := NewReader(strings.NewReader("a,b\nc,\"d\n"))
+	if err := r.SkipRecords(2); !errors.Is(err, ErrUnterminatedQuote) {
+		t.Fatalf("SkipRecords() error = %v, want ErrUnterminatedQuote", err)
+	}
+}
+
+func TestReaderStats(t *testing.T) {
+	t.Parallel()
+
+	const input = "# note\nid,\"name\",tags\n1,\"a\nb\",\"x\"\n2,c\n3,d,e\n"
+
+	r := NewReader(strings.NewReader(input))
+	r.Comment = '#'
+	if got := r.Stats(); got != (ReaderStats{}) {
+		t.Fatalf("Stats() before Read = %+v, want zero", got)
+	}
+	for {
+		_, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil && !errors.Is(err, ErrorFieldCount) {
+			t.Fatalf("Read() error = %v", err)
+		}
+	}
+
+	want := ReaderStats{Records: 4, Bytes: int64(len(input)), MinFields: 2, MaxFields: 3, QuotedFields: 3}
+	if got := r.Stats(); got != want {
+		t.Fatalf("Stats() = %+v, want %+v", got, want)
+	}
+}
+
+func TestReaderInternStrings(t *testing.T) {
+	t.Parallel()
+
+	const input = "country,city\nUS,NYC\nDE,Berlin\nUS,\"NYC\"\nUS,LA\n"
+
+	for _, reuse := range []bool{false, true} {
+		r := NewReader(strings.NewReader(input))
+		r.InternStrings = true
+		r.ReuseRecord = reuse
+		var records [][]string
+		for {
+			record, err := r.Read()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("Read() error = %v", err)
+			}
+			records = append(records, slices.Clone(record))
+		}
+
+		want := [][]string{{"country", "city"}, {"US", "NYC"}, {"DE", "Berlin"}, {"US", "NYC"}, {"US", "LA"}}
+		if !reflect.DeepEqual(records, want) {
+			t.Fatalf("ReuseRecord=%v: records = %#v, want %#v", reuse, records, want)
+		}
+		same := func(a, b string) bool { return unsafe.StringData(a) == unsafe.StringData(b) }
+		if !same(records[1][0], records[3][0]) || !same(records[1][0], records[4][0]) || !same(records[1][1], records[3][1]) {
+			t.Fatalf("ReuseRecord=%v: repeated values do not share storage", reuse)
+		}
+		if same(records[1][0], records[2][0]) {
+			t.Fatalf("ReuseRecord=%v: distinct values share storage", reuse)
+		}
+	}
+}
+
+func TestReaderReadAllAnnotated(t *testing.T) {
+	t.Parallel()
+
+	r := NewReader(strings.NewReader("id,name\n1,a\n2\n3,\"multi\nline\"\n4,d,extra\n5,e\n"))
+	got, err := r.ReadAllAnnotated()
+	if err != nil {
+		t.Fatalf("ReadAllAnnotated() error = %v", err)
+	}
+	want := []AnnotatedRecord{
+		{Fields: []string{"id", "name"}, Line: 1, WidthOK: true},
+		{Fields: []string{"1", "a"}, Line: 2, WidthOK: true},
+		{Fields: []string{"2"}, Line: 3},
+		{Fields: []string{"3", "multi\nline"}, Line: 4, WidthOK: true},
+		{Fields: []string{"4", "d", "extra"}, Line: 6},
+		{Fields: []string{"5", "e"}, Line: 7, WidthOK: true},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("ReadAllAnnotated() mismatch:\n got: %#v\nwant: %#v", got, want)
+	}
+
+	r = NewReader(strings.NewReader("a,b\nc\nd,\"e\n"))
+	got, err = r.ReadAllAnnotated()
+	if !errors.Is(err, ErrUnterminatedQuote) {
+		t.Fatalf("ReadAllAnnotated() error = %v, want ErrUnterminatedQuote", err)
+	}
+	if len(got) != 2 || got[1].WidthOK {
+		t.Fatalf("ReadAllAnnotated() before error = %#v, want two records with the second flagged", got)
+	}
+}
+
+func TestReaderTrimSpace(t *testing.T) {
+	t.Parallel()
+
+	const input = "  a ,\tb\t,\" x \",  ,\"\t\"\n"
+
+	tests := []struct {
+		name         string
+		trim         bool
+		insideQuotes bool
+		want         []string
+	}{
+		{name: "off", want: []string{"  a ", "\tb\t", " x ", "  ", "\t"}},
+		{name: "unquotedOnly", trim: true, want: []string{"a", "b", " x ", "", "\t"}},
+		{name: "insideQuotes", trim: true, insideQuotes: true, want: []string{"a", "b", "x", "", ""}},
+		{name: "insideQuotesWithoutTrim", insideQuotes: true, want: []string{"  a ", "\tb\t", " x ", "  ", "\t"}},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := NewReader(strings.NewReader(input))
+			r.TrimSpace = tc.trim
+			r.TrimInsideQuotes = tc.insideQuotes
+			got, err := r.Read()
+			if err != nil {
+				t.Fatalf("Read() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("Read() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestReaderTrimSpaceDedupe(t *testing.T) {
+	t.Parallel()
+
+	r := NewReader(strings.NewReader("a,b\n a , b\nc,d\n"))
+	r.TrimSpace = true
+	r.DedupeConsecutive = true
+	records, err := r.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+	if want := [][]string{{"a", "b"}, {"c", "d"}}; !reflect.DeepEqual(records, want) {
+		t.Fatalf("ReadAll() = %#v, want %#v", records, want)
+	}
 }
 
 func TestNewReaderNilPanics(t *testing.T) {
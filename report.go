@@ -0,0 +1,97 @@
+package swiftcsv
+
+import (
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// ReportWriter writes CSV for human readers, soft-wrapping long field values onto continuation
+// lines. A field longer than WrapWidth runes is broken at the last space that keeps each line
+// within the width, the spaces at the break being replaced by \n; a word longer than the width
+// is split inside the word. Line breaks already present in a field are kept. Wrapped fields are quoted
+// like any field containing a line break, so the output remains valid CSV that any reader
+// parses as multi-line quoted fields, although the values then contain the inserted breaks.
+type ReportWriter struct {
+	w *Writer
+	// WrapWidth is the maximum number of runes per line within a field. Zero or a negative
+	// value disables wrapping.
+	WrapWidth int
+
+	wrapped []string
+}
+
+// NewReportWriter creates a ReportWriter that writes to w using the default Writer settings.
+func NewReportWriter(w io.Writer, wrapWidth int) *ReportWriter {
+	return &ReportWriter{w: NewWriter(w), WrapWidth: wrapWidth}
+}
+
+// Write wraps the fields of record and writes them as one CSV record. record is not modified.
+func (r *ReportWriter) Write(record []string) error {
+	if r == nil {
+		return errNilWriter
+	}
+	r.wrapped = r.wrapped[:0]
+	for _, field := range record {
+		r.wrapped = append(r.wrapped, wrapField(field, r.WrapWidth))
+	}
+	return r.w.Write(r.wrapped)
+}
+
+// WriteAll writes multiple records, stopping at the first error.
+func (r *ReportWriter) WriteAll(records [][]string) error {
+	for _, record := range records {
+		if err := r.Write(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Flush writes any buffered data to the underlying writer.
+func (r *ReportWriter) Flush() error {
+	if r == nil {
+		return errNilWriter
+	}
+	return r.w.Flush()
+}
+
+// wrapField breaks each line of field that is longer than width runes.
+func wrapField(field string, width int) string {
+	if width <= 0 || utf8.RuneCountInString(field) <= width {
+		return field
+	}
+	var b strings.Builder
+	b.Grow(len(field) + len(field)/width)
+	for i, line := range strings.Split(field, "\n") {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+		for utf8.RuneCountInString(line) > width {
+			// cut ends the first width runes; a space right after them is still a valid break.
+			cut := runeOffset(line, width)
+			_, size := utf8.DecodeRuneInString(line[cut:])
+			if sp := strings.LastIndexByte(line[:cut+size], ' '); sp > 0 {
+				b.WriteString(strings.TrimRight(line[:sp], " "))
+				line = strings.TrimLeft(line[sp+1:], " ")
+			} else {
+				b.WriteString(line[:cut])
+				line = line[cut:]
+			}
+			b.WriteByte('\n')
+		}
+		b.WriteString(line)
+	}
+	return b.String()
+}
+
+// runeOffset returns the byte offset of the rune at index n in s.
+func runeOffset(s string, n int) int {
+	for i := range s {
+		if n == 0 {
+			return i
+		}
+		n--
+	}
+	return len(s)
+}
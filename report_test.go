@@ -0,0 +1,75 @@
+package swiftcsv
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestReportWriter(t *testing.T) {
+	t.Parallel()
+
+	records := [][]string{
+		{"id", "desc"},
+		{"1", "the quick brown fox jumps over the lazy dog"},
+		{"2", "short"},
+		{"3", "supercalifragilistic word"},
+		{"4", "keeps\nexisting breaks and wraps"},
+	}
+	var buf bytes.Buffer
+	w := NewReportWriter(&buf, 10)
+	if err := w.WriteAll(records); err != nil {
+		t.Fatalf("WriteAll() error = %v", err)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	want := "id,desc\n" +
+		"1,\"the quick\nbrown fox\njumps over\nthe lazy\ndog\"\n" +
+		"2,short\n" +
+		"3,\"supercalif\nragilistic\nword\"\n" +
+		"4,\"keeps\nexisting\nbreaks and\nwraps\"\n"
+	if got := buf.String(); got != want {
+		t.Fatalf("unexpected output:\n got: %q\nwant: %q", got, want)
+	}
+	if records[1][1] != "the quick brown fox jumps over the lazy dog" {
+		t.Fatalf("Write() modified the caller's record: %q", records[1][1])
+	}
+
+	got, err := NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+	if len(got) != len(records) || got[1][1] != "the quick\nbrown fox\njumps over\nthe lazy\ndog" {
+		t.Fatalf("ReadAll() = %#v, want the wrapped records", got)
+	}
+}
+
+func TestWrapField(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		field string
+		width int
+		want  string
+	}{
+		{name: "disabled", field: "a b c d", width: 0, want: "a b c d"},
+		{name: "fits", field: "abc def", width: 7, want: "abc def"},
+		{name: "breakAfterWidth", field: "abc def", width: 3, want: "abc\ndef"},
+		{name: "interiorSpacing", field: "a  b  c  d", width: 5, want: "a  b\nc  d"},
+		{name: "runes", field: "ünï cödé ñ", width: 4, want: "ünï\ncödé\nñ"},
+		{name: "longWord", field: "abcdefgh", width: 3, want: "abc\ndef\ngh"},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := wrapField(tc.field, tc.width); got != tc.want {
+				t.Fatalf("wrapField(%q, %d) = %q, want %q", tc.field, tc.width, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package swiftcsv
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrUnknownColumn is returned by SchemaWriter.Write in strict mode when a map holds a key that
+// is not one of the schema columns.
+var ErrUnknownColumn = errors.New("swiftcsv: unknown column")
+
+// SchemaWriter writes maps as records in a fixed column order. The column names are written
+// as a header before the first record. By default the writer is strict and rejects maps with
+// keys outside the schema, which catches misspelt keys; keys missing from a map are written as
+// empty fields either way.
+type SchemaWriter struct {
+	w       *Writer
+	columns []string
+	known   map[string]struct{}
+	record  []string
+	started bool
+
+	// Lenient ignores map keys that are not schema columns instead of rejecting the map.
+	Lenient bool
+}
+
+// NewSchemaWriter creates a SchemaWriter that writes to w with the given column order. The
+// columns are copied.
+func NewSchemaWriter(w io.Writer, columns []string) *SchemaWriter {
+	s := &SchemaWriter{
+		w:       NewWriter(w),
+		columns: cloneRecord(columns),
+		known:   make(map[string]struct{}, len(columns)),
+		record:  make([]string, len(columns)),
+	}
+	for _, name := range s.columns {
+		s.known[name] = struct{}{}
+	}
+	return s
+}
+
+// Write writes row as one record in schema order, preceded by the header on the first call.
+// In strict mode a row with a key outside the schema is rejected with an error wrapping
+// ErrUnknownColumn that names the key, and nothing is written.
+func (s *SchemaWriter) Write(row map[string]string) error {
+	if s == nil {
+		return errNilWriter
+	}
+	if !s.Lenient {
+		unknown, found := "", false
+		for key := range row {
+			// Report the smallest unknown key so the error does not depend on map order.
+			if _, ok := s.known[key]; !ok && (!found || key < unknown) {
+				unknown, found = key, true
+			}
+		}
+		if found {
+			return fmt.Errorf("%w: %q", ErrUnknownColumn, unknown)
+		}
+	}
+	if !s.started {
+		if err := s.w.Write(s.columns); err != nil {
+			return err
+		}
+		s.started = true
+	}
+	for i, name := range s.columns {
+		s.record[i] = row[name]
+	}
+	return s.w.Write(s.record)
+}
+
+// Flush writes any buffered data to the underlying writer.
+func (s *SchemaWriter) Flush() error {
+	if s == nil {
+		return errNilWriter
+	}
+	return s.w.Flush()
+}
@@ -0,0 +1,61 @@
+package swiftcsv
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSchemaWriter(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	columns := []string{"id", "name", "email"}
+	sw := NewSchemaWriter(&buf, columns)
+	columns[0] = "mutated"
+
+	if err := sw.Write(map[string]string{"id": "1", "nmae": "Ann", "emial": "x"}); !errors.Is(err, ErrUnknownColumn) {
+		t.Fatalf("Write() error = %v, want ErrUnknownColumn", err)
+	} else if !strings.Contains(err.Error(), `"emial"`) {
+		t.Fatalf("Write() error = %v, want it to name the key \"emial\"", err)
+	}
+	for _, row := range []map[string]string{
+		{"email": "ann@example.com", "id": "1", "name": "Ann"},
+		{"id": "2"},
+		{},
+	} {
+		if err := sw.Write(row); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+	if err := sw.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	want := "id,name,email\n1,Ann,ann@example.com\n2,,\n,,\n"
+	if got := buf.String(); got != want {
+		t.Fatalf("unexpected output:\n got: %q\nwant: %q", got, want)
+	}
+}
+
+func TestSchemaWriterLenient(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	sw := NewSchemaWriter(&buf, []string{"id", "name"})
+	sw.Lenient = true
+	if err := sw.Write(map[string]string{"id": "1", "name": "a,b", "extra": "ignored"}); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	sw.Lenient = false
+	if err := sw.Write(map[string]string{"": "x"}); !errors.Is(err, ErrUnknownColumn) {
+		t.Fatalf("Write() with empty key error = %v, want ErrUnknownColumn", err)
+	}
+	if err := sw.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	if got, want := buf.String(), "id,name\n1,\"a,b\"\n"; got != want {
+		t.Fatalf("unexpected output:\n got: %q\nwant: %q", got, want)
+	}
+}
@@ -0,0 +1,72 @@
+package swiftcsv
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// TableWriter renders records as space-padded, column-aligned text for human-readable dumps.
+// The output is not CSV: fields are neither quoted nor escaped. Rows are buffered until Flush,
+// which is when column widths are known.
+//
+// Widths are measured in runes, so East Asian wide characters, combining marks, and other
+// characters whose display width differs from one cell will misalign their columns.
+type TableWriter struct {
+	dst    io.Writer
+	rows   [][]string
+	widths []int
+}
+
+// NewTableWriter creates a TableWriter that emits aligned rows to w on Flush.
+func NewTableWriter(w io.Writer) *TableWriter {
+	if w == nil {
+		panic(errWriterNoTarget.Error())
+	}
+	return &TableWriter{dst: w}
+}
+
+// Write buffers a copy of record and widens the tracked column widths as needed.
+func (t *TableWriter) Write(record []string) error {
+	if t == nil {
+		return errNilWriter
+	}
+	row := cloneRecord(record)
+	for i, field := range row {
+		n := utf8.RuneCountInString(field)
+		if i == len(t.widths) {
+			t.widths = append(t.widths, n)
+		} else if n > t.widths[i] {
+			t.widths[i] = n
+		}
+	}
+	t.rows = append(t.rows, row)
+	return nil
+}
+
+// Flush writes every buffered row with columns padded to their widest value and separated by
+// two spaces, then discards the buffered rows. The last field of a row is never padded.
+func (t *TableWriter) Flush() error {
+	if t == nil {
+		return errNilWriter
+	}
+	bw := bufio.NewWriterSize(t.dst, defaultBufferSize)
+	for _, row := range t.rows {
+		for i, field := range row {
+			if i > 0 {
+				bw.WriteString("  ")
+			}
+			bw.WriteString(field)
+			if i < len(row)-1 {
+				if pad := t.widths[i] - utf8.RuneCountInString(field); pad > 0 {
+					bw.WriteString(strings.Repeat(" ", pad))
+				}
+			}
+		}
+		bw.WriteByte('\n')
+	}
+	t.rows = t.rows[:0]
+	t.widths = t.widths[:0]
+	return bw.Flush()
+}
@@ -0,0 +1,65 @@
+package swiftcsv
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestTableWriter(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	tw := NewTableWriter(&buf)
+
+	record := []string{"id", "name", "price"}
+	if err := tw.Write(record); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	record[1] = "mutated"
+	for _, rec := range [][]string{
+		{"1", "Widget", "12.50"},
+		{"1000", "Ünïcödé", "3"},
+		{"7", "Gadget with long name"},
+	} {
+		if err := tw.Write(rec); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	want := "" +
+		"id    name                   price\n" +
+		"1     Widget                 12.50\n" +
+		"1000  Ünïcödé                3\n" +
+		"7     Gadget with long name\n"
+	if got := buf.String(); got != want {
+		t.Fatalf("unexpected output:\n got: %q\nwant: %q", got, want)
+	}
+
+	buf.Reset()
+	if err := tw.Write([]string{"a", "b"}); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if err := tw.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	if got, want := buf.String(), "a  b\n"; got != want {
+		t.Fatalf("second Flush() output got %q want %q", got, want)
+	}
+}
+
+func TestTableWriterFlushError(t *testing.T) {
+	t.Parallel()
+
+	exp := errors.New("write failed")
+	tw := NewTableWriter(&flushFailWriter{fail: exp})
+	if err := tw.Write([]string{"a"}); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if err := tw.Flush(); !errors.Is(err, exp) {
+		t.Fatalf("Flush() error = %v, want %v", err, exp)
+	}
+}
@@ -0,0 +1,155 @@
+package swiftcsv
+
+import (
+	"io"
+)
+
+// Tail returns up to the last n records remaining in the stream, consuming the reader in
+// the process. When the source implements io.Seeker, Tail scans backward from the end in
+// buffer-sized chunks, using quote parity to tell record terminators apart from newlines
+// embedded in quoted fields, and then parses only the located suffix. Line numbers reported
+// in errors are not meaningful after a seeking Tail because the skipped region is never
+// counted. Other sources fall back to a forward scan that keeps the last n records in a ring
+// buffer, as do readers whose state the backward scan cannot account for: a multi-byte
+// QuoteRune, records pushed back by GuessHasHeader or InferSchema, or a positive MaxRecords,
+// so that Tail returns the last of the records Read would have returned. The returned records
+// never share storage, even when ReuseRecord is set.
+func (r *Reader) Tail(n int) ([][]string, error) {
+	if r == nil || r.src == nil || n <= 0 {
+		return nil, nil
+	}
+
+	reuse := r.ReuseRecord
+	r.ReuseRecord = false
+	defer func() { r.ReuseRecord = reuse }()
+
+	if seeker, ok := r.tailSeeker(); ok {
+		if cur, err := seeker.Seek(0, io.SeekCurrent); err == nil {
+			origin := cur - int64(r.bufLen-r.bufPos)
+			start, err := r.tailStart(seeker, origin, n)
+			if err != nil {
+				// The failed scan moved the seeker and overwrote the buffer, so the forward
+				// scan restarts from the reader's logical position.
+				start = origin
+			}
+			if _, err := seeker.Seek(start, io.SeekStart); err != nil {
+				return nil, err
+			}
+			r.bufPos, r.bufLen, r.bufErr = 0, 0, nil
+			if err == nil {
+				return r.ReadAll()
+			}
+		}
+	}
+
+	ring := make([][]string, n)
+	count := 0
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		ring[count%n] = record
+		count++
+	}
+
+	if count < n {
+		return ring[:count], nil
+	}
+	out := make([][]string, 0, n)
+	out = append(out, ring[count%n:]...)
+	return append(out, ring[:count%n]...), nil
+}
+
+// tailSeeker returns the source as an io.Seeker when the backward scan of Tail yields the same
+// records as the forward scan would.
+func (r *Reader) tailSeeker() (io.Seeker, bool) {
+	seeker, ok := r.src.(io.Seeker)
+	switch {
+	case !ok, r.finished:
+		return nil, false
+	case r.EffectiveQuote() == 0:
+		// A multi-byte QuoteRune cannot be matched byte by byte.
+		return nil, false
+	case len(r.pending) > 0:
+		// Pushed-back records are already out of the source.
+		return nil, false
+	case r.MaxRecords > 0:
+		// The limit counts from the front of the stream, which the backward scan skips.
+		return nil, false
+	}
+	return seeker, true
+}
+
+// tailStart walks seeker backward from its end and returns the offset of the first of the
+// last n records, never reaching before start, the reader's logical position. A terminator,
+// \n and \r or the RecordTerminators, only separates records when an even number of quote
+// characters follows it.
+func (r *Reader) tailStart(seeker io.Seeker, start int64, n int) (int64, error) {
+	quote := r.EffectiveQuote()
+	// An asymmetric pair contributes one open and one close byte per quoted field, so counting
+	// both keeps the parity rule intact.
+	open, closing := quote, quote
+	if r.QuoteOpen != 0 {
+		open = r.QuoteOpen
+	}
+	if r.QuoteClose != 0 {
+		closing = r.QuoteClose
+	}
+	terminators := [256]bool{'\n': true, '\r': true}
+	if len(r.RecordTerminators) > 0 {
+		terminators = [256]bool{}
+		for _, c := range r.RecordTerminators {
+			terminators[c] = true
+		}
+	}
+
+	end, err := seeker.Seek(0, io.SeekEnd)
+	if err != nil {
+		return 0, err
+	}
+
+	quotes := 0
+	boundaries := 0
+	next := -1 // byte following the current position in file order, -1 at EOF
+	pos := end
+	for pos > start {
+		chunk := int64(len(r.buf))
+		if pos-start < chunk {
+			chunk = pos - start
+		}
+		pos -= chunk
+		if _, err := seeker.Seek(pos, io.SeekStart); err != nil {
+			return 0, err
+		}
+		data := r.buf[:chunk]
+		if _, err := io.ReadFull(r.src, data); err != nil {
+			return 0, err
+		}
+
+		for i := len(data) - 1; i >= 0; i-- {
+			b := data[i]
+			switch {
+			case b == open || b == closing:
+				quotes++
+			case terminators[b] && quotes%2 == 0:
+				// CRLF is counted once at its '\n'; the final terminator ends the last record.
+				if b == '\r' && next == '\n' && terminators['\n'] {
+					break
+				}
+				if pos+int64(i)+1 == end {
+					break
+				}
+				boundaries++
+				if boundaries == n {
+					return pos + int64(i) + 1, nil
+				}
+			}
+			next = int(b)
+		}
+	}
+	return start, nil
+}
@@ -0,0 +1,291 @@
+package swiftcsv
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// readOnly hides any io.Seeker implementation of the wrapped reader.
+type readOnly struct {
+	io.Reader
+}
+
+// failingScan fails the second read after a seek to the end, breaking the backward scan once
+// it has already moved the seeker and overwritten the reader's buffer.
+type failingScan struct {
+	*strings.Reader
+	reads int
+}
+
+func (f *failingScan) Seek(offset int64, whence int) (int64, error) {
+	if whence == io.SeekEnd {
+		f.reads = 2
+	}
+	return f.Reader.Seek(offset, whence)
+}
+
+func (f *failingScan) Read(p []byte) (int, error) {
+	if f.reads > 0 {
+		if f.reads--; f.reads == 0 {
+			return 0, errors.New("scan failed")
+		}
+	}
+	return f.Reader.Read(p)
+}
+
+func TestReaderTail(t *testing.T) {
+	t.Parallel()
+
+	var big strings.Builder
+	for i := 0; i < 500; i++ {
+		fmt.Fprintf(&big, "%d,\"row\n%d\",\"q\"\"%d\"\r\n", i, i, i)
+	}
+
+	tests := []struct {
+		name  string
+		input string
+		n     int
+		want  [][]string
+	}{
+		{
+			name:  "lastTwo",
+			input: "a,1\nb,2\nc,3\n",
+			n:     2,
+			want:  [][]string{{"b", "2"}, {"c", "3"}},
+		},
+		{
+			name:  "noTrailingNewline",
+			input: "a,1\nb,2\nc,3",
+			n:     1,
+			want:  [][]string{{"c", "3"}},
+		},
+		{
+			name:  "fewerThanRequested",
+			input: "a,1\nb,2\n",
+			n:     5,
+			want:  [][]string{{"a", "1"}, {"b", "2"}},
+		},
+		{
+			name:  "embeddedNewlines",
+			input: "a,\"x\ny\"\nb,\"p\r\nq\"\r\nc,\"\"\"\n\"\"\"\n",
+			n:     2,
+			want:  [][]string{{"b", "p\r\nq"}, {"c", "\"\n\""}},
+		},
+		{
+			name:  "spansChunks",
+			input: big.String(),
+			n:     3,
+			want: [][]string{
+				{"497", "row\n497", "q\"497"},
+				{"498", "row\n498", "q\"498"},
+				{"499", "row\n499", "q\"499"},
+			},
+		},
+		{
+			name:  "empty",
+			input: "",
+			n:     3,
+			want:  [][]string{},
+		},
+	}
+
+	sources := map[string]func(string) io.Reader{
+		"seekable":    func(s string) io.Reader { return strings.NewReader(s) },
+		"nonSeekable": func(s string) io.Reader { return readOnly{strings.NewReader(s)} },
+	}
+
+	for _, tc := range tests {
+		for srcName, src := range sources {
+			tc, src := tc, src
+			t.Run(tc.name+"/"+srcName, func(t *testing.T) {
+				t.Parallel()
+
+				r := NewReader(src(tc.input))
+				r.ReuseRecord = true
+				got, err := r.Tail(tc.n)
+				if err != nil {
+					t.Fatalf("Tail() error = %v", err)
+				}
+				if len(got) == 0 && len(tc.want) == 0 {
+					return
+				}
+				if !reflect.DeepEqual(got, tc.want) {
+					t.Fatalf("Tail() mismatch:\n got: %#v\nwant: %#v", got, tc.want)
+				}
+				if _, err := r.Read(); err != io.EOF {
+					t.Fatalf("Read() after Tail error = %v, want io.EOF", err)
+				}
+			})
+		}
+	}
+}
+
+func TestReaderTailQuotePair(t *testing.T) {
+	t.Parallel()
+
+	r := NewReader(strings.NewReader("a,[x\ny]\nb,[p\n\"q]\nc,[z]\n"))
+	r.QuoteOpen, r.QuoteClose = '[', ']'
+	got, err := r.Tail(2)
+	if err != nil {
+		t.Fatalf("Tail() error = %v", err)
+	}
+	if want := [][]string{{"b", "p\n\"q"}, {"c", "z"}}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("Tail() mismatch:\n got: %#v\nwant: %#v", got, want)
+	}
+}
+
+func TestReaderTailQuoteRune(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		quote rune
+		input string
+		want  [][]string
+	}{
+		{
+			name:  "multiByte",
+			quote: '«',
+			input: "1,a\n2,«multi\nline, \"x\"«\n3,«y«\n",
+			want:  [][]string{{"2", "multi\nline, \"x\""}, {"3", "y"}},
+		},
+		{
+			name:  "ascii",
+			quote: '\'',
+			input: "1,a\n2,'multi\nline, \"x'\n3,'y'\n",
+			want:  [][]string{{"2", "multi\nline, \"x"}, {"3", "y"}},
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := NewReader(strings.NewReader(tc.input))
+			r.QuoteRune = tc.quote
+			got, err := r.Tail(2)
+			if err != nil {
+				t.Fatalf("Tail() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("Tail() mismatch:\n got: %#v\nwant: %#v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestReaderTailAfterRead(t *testing.T) {
+	t.Parallel()
+
+	r := NewReader(strings.NewReader("h1,h2\na,1\nb,2\n"))
+	if _, err := r.Read(); err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+
+	got, err := r.Tail(10)
+	if err != nil {
+		t.Fatalf("Tail() error = %v", err)
+	}
+	want := [][]string{{"a", "1"}, {"b", "2"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Tail() mismatch:\n got: %#v\nwant: %#v", got, want)
+	}
+}
+
+func TestReaderTailScanFailure(t *testing.T) {
+	t.Parallel()
+
+	var input strings.Builder
+	var want [][]string
+	input.WriteString("id,value\n")
+	for i := 0; i < 3*defaultBufferSize/10; i++ {
+		fmt.Fprintf(&input, "%d,\"v\n%d\"\n", i, i)
+		want = append(want, []string{fmt.Sprint(i), fmt.Sprintf("v\n%d", i)})
+	}
+	r := NewReader(&failingScan{Reader: strings.NewReader(input.String())})
+	if _, err := r.Read(); err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+
+	got, err := r.Tail(len(want) + 1)
+	if err != nil {
+		t.Fatalf("Tail() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Tail() returned %d records starting %q, want %d starting %q", len(got), got[:min(len(got), 1)], len(want), want[0])
+	}
+}
+
+// tailBoth runs Tail(n) over input from a seekable and a non-seekable source, configured by
+// prepare, and fails unless both return want.
+func tailBoth(t *testing.T, input string, n int, prepare func(*Reader) error, want [][]string) {
+	t.Helper()
+
+	for name, src := range map[string]io.Reader{
+		"seekable":    strings.NewReader(input),
+		"nonSeekable": readOnly{strings.NewReader(input)},
+	} {
+		r := NewReader(src)
+		if prepare != nil {
+			if err := prepare(r); err != nil {
+				t.Fatalf("%s: prepare error = %v", name, err)
+			}
+		}
+		got, err := r.Tail(n)
+		if err != nil {
+			t.Fatalf("%s: Tail() error = %v", name, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("%s: Tail() mismatch:\n got: %#v\nwant: %#v", name, got, want)
+		}
+	}
+}
+
+func TestReaderTailAfterPushBack(t *testing.T) {
+	t.Parallel()
+
+	const input = "name,qty\na,1\nb,2\nc,3\nd,4\n"
+	want := [][]string{{"c", "3"}, {"d", "4"}}
+	tailBoth(t, input, 2, func(r *Reader) error {
+		_, err := r.InferSchema(3)
+		return err
+	}, want)
+	tailBoth(t, input, 2, func(r *Reader) error {
+		_, err := r.GuessHasHeader()
+		return err
+	}, want)
+	tailBoth(t, input, 10, func(r *Reader) error {
+		_, err := r.InferSchema(3)
+		return err
+	}, [][]string{{"name", "qty"}, {"a", "1"}, {"b", "2"}, {"c", "3"}, {"d", "4"}})
+}
+
+func TestReaderTailRecordTerminators(t *testing.T) {
+	t.Parallel()
+
+	terminators := func(list string) func(*Reader) error {
+		return func(r *Reader) error {
+			r.RecordTerminators = []byte(list)
+			return nil
+		}
+	}
+	tailBoth(t, "a\x1eb\x1ec\x1e", 1, terminators("\x1e"), [][]string{{"c"}})
+	tailBoth(t, "a\x1eb\nx\x1e\"c\x1ed\"\x1e", 2, terminators("\x1e"), [][]string{{"b\nx"}, {"c\x1ed"}})
+	tailBoth(t, "a\r\nb\r\nc", 2, terminators("\r"), [][]string{{"\nb"}, {"\nc"}})
+	tailBoth(t, "a\r\nb\fc\r\n", 2, terminators("\r\n\f"), [][]string{{"b"}, {"c"}})
+}
+
+func TestReaderTailMaxRecords(t *testing.T) {
+	t.Parallel()
+
+	limit := func(r *Reader) error {
+		r.MaxRecords = 3
+		return nil
+	}
+	tailBoth(t, "1\n2\n3\n4\n5\n", 2, limit, [][]string{{"2"}, {"3"}})
+}
@@ -0,0 +1,182 @@
+package swiftcsv
+
+import (
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ColumnType selects the Go type a column is converted to by ReadTyped.
+type ColumnType int
+
+const (
+	// TypeString keeps the field as a string. It is the zero value.
+	TypeString ColumnType = iota
+	// TypeInt converts the field to an int64.
+	TypeInt
+	// TypeFloat converts the field to a float64.
+	TypeFloat
+	// TypeBool converts the field to a bool using strconv.ParseBool.
+	TypeBool
+)
+
+// String returns the name of the column type.
+func (t ColumnType) String() string {
+	switch t {
+	case TypeString:
+		return "string"
+	case TypeInt:
+		return "int"
+	case TypeFloat:
+		return "float"
+	case TypeBool:
+		return "bool"
+	}
+	return "ColumnType(" + strconv.Itoa(int(t)) + ")"
+}
+
+// ReadTyped reads the next record and converts field i according to schema[i], producing
+// string, int64, float64, or bool values. Columns beyond a nil or short schema stay strings.
+// A failed conversion returns a *ParseError whose Line is the record's starting line, whose
+// Column is the 1-based index of the offending field, and whose Err is the strconv error.
+func (r *Reader) ReadTyped(schema []ColumnType) ([]any, error) {
+	if r == nil {
+		return nil, io.EOF
+	}
+	record, err := r.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]any, len(record))
+	for i, field := range record {
+		typ := TypeString
+		if i < len(schema) {
+			typ = schema[i]
+		}
+		v, err := convertField(field, typ, r.DecimalSeparator)
+		if err != nil {
+			return nil, &ParseError{Line: r.recordLine, Column: i + 1, Err: err}
+		}
+		out[i] = v
+	}
+	return out, nil
+}
+
+// InferSchema samples up to sampleRows upcoming records and infers a ColumnType per column,
+// returning one entry per column of the widest sampled record. A column is TypeInt when every
+// sampled value parses as an integer, otherwise TypeFloat when every value parses as a float,
+// otherwise TypeBool when every value parses as a bool, and TypeString otherwise; empty values
+// count as strings. The sampled records are buffered and replayed by subsequent reads, and a
+// parse error ends sampling early and is returned after the records before it are buffered.
+func (r *Reader) InferSchema(sampleRows int) ([]ColumnType, error) {
+	if r == nil || r.src == nil {
+		return nil, io.EOF
+	}
+
+	// candidates[i] holds the conversions every sampled value of column i has satisfied so far.
+	const canInt, canFloat, canBool = 1 << TypeInt, 1 << TypeFloat, 1 << TypeBool
+	var candidates []int
+	observe := func(data []byte, bounds []int) {
+		for i := 0; i < len(bounds)/2; i++ {
+			if i == len(candidates) {
+				candidates = append(candidates, canInt|canFloat|canBool)
+			}
+			field := string(data[bounds[2*i]:bounds[2*i+1]])
+			if _, err := strconv.ParseInt(field, 10, 64); err != nil {
+				candidates[i] &^= canInt
+			}
+			if _, err := parseFloat(field, r.DecimalSeparator); err != nil {
+				candidates[i] &^= canFloat
+			}
+			if _, err := strconv.ParseBool(field); err != nil {
+				candidates[i] &^= canBool
+			}
+		}
+	}
+
+	sampled := 0
+	for _, p := range r.pending {
+		if sampled == sampleRows {
+			break
+		}
+		observe(p.data, p.bounds)
+		sampled++
+	}
+	var err error
+	for ; sampled < sampleRows; sampled++ {
+		if err = r.nextRecord(); err != nil {
+			break
+		}
+		observe(r.dataBuf, r.fieldBounds)
+		r.pushBack()
+	}
+	if err == io.EOF {
+		err = nil
+	}
+
+	var schema []ColumnType
+	for _, c := range candidates {
+		switch {
+		case c&canInt != 0:
+			schema = append(schema, TypeInt)
+		case c&canFloat != 0:
+			schema = append(schema, TypeFloat)
+		case c&canBool != 0:
+			schema = append(schema, TypeBool)
+		default:
+			schema = append(schema, TypeString)
+		}
+	}
+	return schema, err
+}
+
+// ReadNullable reads the next record and returns a pointer per field, using nil for fields
+// exactly equal to NullText so that NULL values survive a round trip. An empty NullText
+// disables the mapping and every field is returned as a non-nil pointer. The pointed-to
+// strings follow the same lifetime rules as Read under ReuseRecord.
+func (r *Reader) ReadNullable() ([]*string, error) {
+	if r == nil {
+		return nil, io.EOF
+	}
+	record, err := r.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]*string, len(record))
+	for i, field := range record {
+		if r.NullText != "" && field == r.NullText {
+			continue
+		}
+		out[i] = &field
+	}
+	return out, nil
+}
+
+// convertField parses field into the Go value selected by typ, reading floats with decimal as
+// the decimal separator when it is non-zero.
+func convertField(field string, typ ColumnType, decimal byte) (any, error) {
+	switch typ {
+	case TypeInt:
+		return strconv.ParseInt(field, 10, 64)
+	case TypeFloat:
+		return parseFloat(field, decimal)
+	case TypeBool:
+		return strconv.ParseBool(field)
+	}
+	return field, nil
+}
+
+// parseFloat parses field as a float64 using decimal, when non-zero, as the decimal separator.
+// A '.' is rejected under a different separator rather than guessed at, since such data often
+// uses it for digit grouping.
+func parseFloat(field string, decimal byte) (float64, error) {
+	if decimal != 0 && decimal != '.' {
+		if strings.IndexByte(field, '.') >= 0 {
+			return 0, &strconv.NumError{Func: "ParseFloat", Num: field, Err: strconv.ErrSyntax}
+		}
+		field = strings.ReplaceAll(field, string([]byte{decimal}), ".")
+	}
+	return strconv.ParseFloat(field, 64)
+}
@@ -0,0 +1,221 @@
+package swiftcsv
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestReaderReadTyped(t *testing.T) {
+	t.Parallel()
+
+	r := NewReader(strings.NewReader("widget,12,3.5,true,extra\ngadget,-7,1e3,F,more\n"))
+	schema := []ColumnType{TypeString, TypeInt, TypeFloat, TypeBool}
+
+	want := [][]any{
+		{"widget", int64(12), 3.5, true, "extra"},
+		{"gadget", int64(-7), 1000.0, false, "more"},
+	}
+	for i, w := range want {
+		got, err := r.ReadTyped(schema)
+		if err != nil {
+			t.Fatalf("ReadTyped() #%d error = %v", i+1, err)
+		}
+		if !reflect.DeepEqual(got, w) {
+			t.Fatalf("ReadTyped() #%d = %#v, want %#v", i+1, got, w)
+		}
+	}
+}
+
+func TestReaderReadTypedNilSchema(t *testing.T) {
+	t.Parallel()
+
+	r := NewReader(strings.NewReader("1,true\n"))
+	got, err := r.ReadTyped(nil)
+	if err != nil {
+		t.Fatalf("ReadTyped() error = %v", err)
+	}
+	if want := []any{"1", "true"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("ReadTyped() = %#v, want %#v", got, want)
+	}
+}
+
+func TestReaderReadTypedConversionError(t *testing.T) {
+	t.Parallel()
+
+	r := NewReader(strings.NewReader("a,1\n\"b\nc\",oops\n"))
+	schema := []ColumnType{TypeString, TypeInt}
+
+	if _, err := r.ReadTyped(schema); err != nil {
+		t.Fatalf("ReadTyped() error = %v", err)
+	}
+	_, err := r.ReadTyped(schema)
+	var perr *ParseError
+	if !errors.As(err, &perr) {
+		t.Fatalf("ReadTyped() error = %v, want *ParseError", err)
+	}
+	if perr.Line != 2 || perr.Column != 2 {
+		t.Fatalf("ParseError location = line %d column %d, want line 2 column 2", perr.Line, perr.Column)
+	}
+	if !errors.Is(err, strconv.ErrSyntax) {
+		t.Fatalf("ReadTyped() error = %v, want strconv.ErrSyntax", err)
+	}
+}
+
+func TestColumnTypeString(t *testing.T) {
+	t.Parallel()
+
+	for typ, want := range map[ColumnType]string{
+		TypeString:    "string",
+		TypeInt:       "int",
+		TypeFloat:     "float",
+		TypeBool:      "bool",
+		ColumnType(9): "ColumnType(9)",
+	} {
+		if got := typ.String(); got != want {
+			t.Fatalf("ColumnType(%d).String() = %q, want %q", int(typ), got, want)
+		}
+	}
+}
+
+func TestReaderReadNullable(t *testing.T) {
+	t.Parallel()
+
+	const input = "1,\\N,alpha\n\\N,,\"\\N\"\n2,\\Nx,\n"
+
+	deref := func(fields []*string) []any {
+		out := make([]any, len(fields))
+		for i, f := range fields {
+			if f != nil {
+				out[i] = *f
+			}
+		}
+		return out
+	}
+
+	t.Run("sentinel", func(t *testing.T) {
+		t.Parallel()
+
+		r := NewReader(strings.NewReader(input))
+		r.NullText = `\N`
+
+		want := [][]any{
+			{"1", nil, "alpha"},
+			{nil, "", nil},
+			{"2", `\Nx`, ""},
+		}
+		for i, w := range want {
+			got, err := r.ReadNullable()
+			if err != nil {
+				t.Fatalf("ReadNullable() record %d error = %v", i, err)
+			}
+			if !reflect.DeepEqual(deref(got), w) {
+				t.Fatalf("ReadNullable() record %d = %#v, want %#v", i, deref(got), w)
+			}
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		t.Parallel()
+
+		r := NewReader(strings.NewReader(input))
+		got, err := r.ReadNullable()
+		if err != nil {
+			t.Fatalf("ReadNullable() error = %v", err)
+		}
+		if want := []any{"1", `\N`, "alpha"}; !reflect.DeepEqual(deref(got), want) {
+			t.Fatalf("ReadNullable() = %#v, want %#v", deref(got), want)
+		}
+	})
+}
+
+func TestReaderInferSchema(t *testing.T) {
+	t.Parallel()
+
+	const input = "1,2.5,true,x,7,1\n-2,3,false,y,8.5,\n30,1e3,T,1,9,0\n4,oops,1,2,10,1\n"
+
+	tests := []struct {
+		name   string
+		sample int
+		want   []ColumnType
+	}{
+		{name: "clean", sample: 3, want: []ColumnType{TypeInt, TypeFloat, TypeBool, TypeString, TypeFloat, TypeString}},
+		{name: "firstRowOnly", sample: 1, want: []ColumnType{TypeInt, TypeFloat, TypeBool, TypeString, TypeInt, TypeInt}},
+		{name: "mixed", sample: 4, want: []ColumnType{TypeInt, TypeString, TypeBool, TypeString, TypeFloat, TypeString}},
+		{name: "beyondInput", sample: 100, want: []ColumnType{TypeInt, TypeString, TypeBool, TypeString, TypeFloat, TypeString}},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			want, err := NewReader(strings.NewReader(input)).ReadAll()
+			if err != nil {
+				t.Fatalf("ReadAll() error = %v", err)
+			}
+
+			r := NewReader(strings.NewReader(input))
+			schema, err := r.InferSchema(tc.sample)
+			if err != nil {
+				t.Fatalf("InferSchema() error = %v", err)
+			}
+			if !reflect.DeepEqual(schema, tc.want) {
+				t.Fatalf("InferSchema() = %v, want %v", schema, tc.want)
+			}
+
+			records, err := r.ReadAll()
+			if err != nil {
+				t.Fatalf("ReadAll() after InferSchema error = %v", err)
+			}
+			if !reflect.DeepEqual(records, want) {
+				t.Fatalf("ReadAll() after InferSchema = %#v, want %#v", records, want)
+			}
+		})
+	}
+}
+
+func TestReaderInferSchemaEmpty(t *testing.T) {
+	t.Parallel()
+
+	schema, err := NewReader(strings.NewReader("")).InferSchema(10)
+	if err != nil || schema != nil {
+		t.Fatalf("InferSchema() = %v, %v; want nil, nil", schema, err)
+	}
+}
+
+func TestReaderReadTypedDecimalSeparator(t *testing.T) {
+	t.Parallel()
+
+	r := NewReader(strings.NewReader("1,50;2,75;-0,125;3\n"))
+	r.Comma = ';'
+	r.DecimalSeparator = ','
+
+	schema := []ColumnType{TypeFloat, TypeFloat, TypeFloat, TypeInt}
+	got, err := r.ReadTyped(schema)
+	if err != nil {
+		t.Fatalf("ReadTyped() error = %v", err)
+	}
+	if want := []any{1.5, 2.75, -0.125, int64(3)}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("ReadTyped() = %#v, want %#v", got, want)
+	}
+
+	r = NewReader(strings.NewReader("1,50;x\n2;y\n"))
+	r.Comma = ';'
+	r.DecimalSeparator = ','
+	inferred, err := r.InferSchema(2)
+	if err != nil {
+		t.Fatalf("InferSchema() error = %v", err)
+	}
+	if want := []ColumnType{TypeFloat, TypeString}; !reflect.DeepEqual(inferred, want) {
+		t.Fatalf("InferSchema() = %v, want %v", inferred, want)
+	}
+
+	r = NewReader(strings.NewReader("1.50\n"))
+	r.DecimalSeparator = ','
+	if _, err := r.ReadTyped([]ColumnType{TypeFloat}); err == nil {
+		t.Fatalf("ReadTyped() accepted '.' with DecimalSeparator ','")
+	}
+}
@@ -2,38 +2,164 @@ package swiftcsv
 
 import (
 	"bufio"
+	"context"
 	"errors"
+	"hash"
 	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
 )
 
 var (
+	// ErrWriterClosed is returned by write operations on a Writer after Close.
+	ErrWriterClosed = errors.New("swiftcsv: writer is closed")
+
+	// ErrUnquotableField is returned when Writer.Quoting is QuoteNone and a field contains the
+	// delimiter, the quote, a line break, or a RecordTerminator byte, or when a field containing
+	// the close byte of an asymmetric QuoteOpen and QuoteClose pair would be quoted. Nothing of
+	// the offending record is written.
+	ErrUnquotableField = errors.New("swiftcsv: field requires quoting but quoting is disabled")
+
+	// ErrFieldTooLong is returned when a field is longer than Writer.MaxFieldLength. Nothing of
+	// the offending record is written.
+	ErrFieldTooLong = errors.New("swiftcsv: field exceeds maximum length")
+
+	// ErrEmptyRecord is returned by Write and WriteQuoted for a zero-length record when
+	// Writer.ErrorOnEmptyRecord is set.
+	ErrEmptyRecord = errors.New("swiftcsv: empty record")
+
 	errNilWriter      = errors.New("swiftcsv: writer is nil")
 	errWriterNoTarget = errors.New("swiftcsv: writer destination cannot be nil")
 )
 
+// QuoteStyle selects when a Writer encloses fields in quotes.
+type QuoteStyle int
+
+const (
+	// QuoteMinimal quotes only fields that require it, plus those selected by QuoteFunc or
+	// QuoteLeadingTrailingSpace. It is the zero value.
+	QuoteMinimal QuoteStyle = iota
+	// QuoteAll quotes every field.
+	QuoteAll
+	// QuoteNonNumeric quotes every field that does not parse as a number, in addition to the
+	// fields QuoteMinimal would quote.
+	QuoteNonNumeric
+	// QuoteNone never quotes and rejects fields that would require quoting with ErrUnquotableField.
+	QuoteNone
+)
+
 // Writer provides high-throughput CSV emission with configurable delimiters and quoting rules.
 type Writer struct {
 	dst *bufio.Writer
+	out io.Writer
 
 	// Comma is the field delimiter. Default is ','.
 	Comma byte
 	// Quote is the quote character. Default is '"'.
 	Quote byte
+	// QuoteOpen and QuoteClose, when non-zero, replace Quote as the bytes written before and
+	// after a quoted field; either one left zero defaults to Quote. When they differ, as with
+	// '[' and ']', fields containing the open byte are quoted, and since such a pair has no
+	// escape, a field containing the close byte is rejected with ErrUnquotableField whenever it
+	// would be quoted. Unquoted fields may contain the close byte freely.
+	QuoteOpen  byte
+	QuoteClose byte
 	// UseCRLF writes records terminated with \r\n when set.
 	UseCRLF bool
-	// AlwaysQuote forces quoting for all fields when enabled.
+	// AlwaysQuote forces quoting for all fields when enabled. It is equivalent to setting Quoting
+	// to QuoteAll and takes effect only while Quoting is QuoteMinimal.
 	AlwaysQuote bool
+	// Quoting selects the quoting policy. The default, QuoteMinimal, quotes only where needed.
+	Quoting QuoteStyle
+	// QuoteFunc, when non-nil, is consulted for fields that need no quoting for correctness and
+	// forces quoting when it returns true, e.g. to preserve leading zeros in numeric-looking values.
+	// Fields containing the delimiter, quote, or line breaks are always quoted.
+	QuoteFunc func(field string) bool
+	// QuoteLeadingTrailingSpace quotes fields that begin or end with a space or tab, which many
+	// importers would otherwise trim, even when no other character requires quoting.
+	QuoteLeadingTrailingSpace bool
+	// SanitizeFormulas prefixes fields beginning with '=', '+', '-', '@', a tab, or a carriage
+	// return with FormulaPrefix so spreadsheet applications do not evaluate them as formulas.
+	// Negative numbers are prefixed as well. The prefix is added before quoting decisions.
+	SanitizeFormulas bool
+	// FormulaPrefix is the byte prepended by SanitizeFormulas. Zero selects '\''.
+	FormulaPrefix byte
+	// MinFieldWidth, when positive, right-pads fields shorter than this many runes with spaces.
+	// Padding happens before quoting decisions, so padded fields that are quoted carry the spaces
+	// inside the quotes, and with QuoteLeadingTrailingSpace every padded field becomes quoted.
+	MinFieldWidth int
+	// Encoder, when non-nil, transcodes field content from UTF-8 to the output encoding, for
+	// example ISO-8859-1. Quoting decisions are made on the original field and the delimiter,
+	// quote, and terminator bytes are written untranscoded.
+	Encoder func([]byte) []byte
+	// RecordTerminator, when non-empty, is written verbatim after each record in place of
+	// the \n or \r\n selected by UseCRLF. Fields containing any of its bytes are quoted.
+	RecordTerminator []byte
+	// TrimTrailingEmpty drops empty fields from the end of each record passed to Write or
+	// WriteQuoted before it is written, so a,b,, is written as a,b and a record of only empty
+	// fields as an empty line. Fields are tested before MinFieldWidth padding is applied.
+	TrimTrailingEmpty bool
+	// ErrorOnEmptyRecord makes Write and WriteQuoted reject a zero-length record with
+	// ErrEmptyRecord instead of writing an empty line. A record that only becomes empty through
+	// TrimTrailingEmpty is still written.
+	ErrorOnEmptyRecord bool
+	// MaxFieldLength, when positive, is the largest field in bytes that Write, WriteQuoted, and
+	// WriteField accept; longer fields are rejected with ErrFieldTooLong. The limit applies to
+	// the field value before quoting, escaping, padding, or encoding. Zero means unlimited.
+	MaxFieldLength int
+	// WriteBOM emits a UTF-8 byte order mark before the first record. The mark is written
+	// once per Writer and is not repeated after Reset.
+	WriteBOM bool
+
+	// quoteColumns marks the column indexes selected by SetColumnQuoting.
+	quoteColumns []bool
+	// fieldIndex counts the fields written by WriteField to the record under construction.
+	fieldIndex int
 
-	err error
+	hash       hash.Hash
+	err        error
+	bomWritten bool
+	closed     bool
+	closeErr   error
 }
 
 // NewWriter creates a new Writer with internal buffering tuned for bulk writes.
 func NewWriter(w io.Writer) *Writer {
+	return NewWriterSize(w, defaultBufferSize)
+}
+
+// NewWriterSize behaves like NewWriter but buffers size bytes before writing to w, so larger
+// buffers mean fewer writes to slow destinations. Non-positive sizes select the default. The
+// size is kept across Reset.
+func NewWriterSize(w io.Writer, size int) *Writer {
 	if w == nil {
 		panic(errWriterNoTarget.Error())
 	}
+	if size <= 0 {
+		size = defaultBufferSize
+	}
 	return &Writer{
-		dst:   bufio.NewWriterSize(w, defaultBufferSize),
+		dst:   bufio.NewWriterSize(w, size),
+		out:   w,
+		Comma: ',',
+		Quote: '"',
+	}
+}
+
+// NewWriterHash creates a Writer that also feeds every byte it emits into h, so that h.Sum
+// reports the checksum of the output once Flush has returned. The hash is kept across Reset.
+func NewWriterHash(w io.Writer, h hash.Hash) *Writer {
+	if w == nil {
+		panic(errWriterNoTarget.Error())
+	}
+	if h == nil {
+		return NewWriter(w)
+	}
+	return &Writer{
+		dst:   bufio.NewWriterSize(io.MultiWriter(w, h), defaultBufferSize),
+		out:   w,
+		hash:  h,
 		Comma: ',',
 		Quote: '"',
 	}
@@ -47,16 +173,36 @@ func (w *Writer) Reset(dst io.Writer) {
 	if dst == nil {
 		panic(errWriterNoTarget.Error())
 	}
+	w.out = dst
+	if w.hash != nil {
+		dst = io.MultiWriter(dst, w.hash)
+	}
 	if w.dst == nil {
 		w.dst = bufio.NewWriterSize(dst, defaultBufferSize)
 	} else {
 		w.dst.Reset(dst)
 	}
 	w.err = nil
+	w.fieldIndex = 0
+	w.closed = false
+	w.closeErr = nil
 }
 
 // Write emits a single CSV record. The record is terminated with the configured newline sequence.
 func (w *Writer) Write(record []string) error {
+	return w.writeRecord(record, nil)
+}
+
+// WriteQuoted emits a record like Write but additionally quotes field i whenever quote[i] is
+// true, which allows quoting styles captured with Reader.FieldQuoted to be reproduced. Fields
+// that require quoting are quoted regardless, and entries missing from a short quote slice
+// count as false.
+func (w *Writer) WriteQuoted(fields []string, quote []bool) error {
+	return w.writeRecord(fields, quote)
+}
+
+// writeRecord emits record followed by the terminator, forcing quotes where force[i] is true.
+func (w *Writer) writeRecord(record []string, force []bool) error {
 	if w == nil {
 		return errNilWriter
 	}
@@ -66,14 +212,33 @@ func (w *Writer) Write(record []string) error {
 	if w.err != nil {
 		return w.err
 	}
+	if len(record) == 0 && w.ErrorOnEmptyRecord {
+		return ErrEmptyRecord
+	}
 
-	comma := w.Comma
-	if comma == 0 {
-		comma = ','
+	if w.TrimTrailingEmpty {
+		for len(record) > 0 && record[len(record)-1] == "" {
+			record = record[:len(record)-1]
+		}
 	}
-	quote := w.Quote
-	if quote == 0 {
-		quote = '"'
+	comma, open, closing := w.delimiters()
+	if w.Quoting == QuoteNone || open != closing {
+		for i, field := range record {
+			if w.unquotable(field, comma, open, closing, w.forced(force, i)) {
+				return ErrUnquotableField
+			}
+		}
+	}
+	if w.MaxFieldLength > 0 {
+		for _, field := range record {
+			if w.tooLong(field) {
+				return ErrFieldTooLong
+			}
+		}
+	}
+	// The BOM is only written once the record is known to be accepted.
+	if err := w.writeBOM(); err != nil {
+		return err
 	}
 
 	for i := range record {
@@ -83,26 +248,132 @@ func (w *Writer) Write(record []string) error {
 				return err
 			}
 		}
-		if err := w.writeField(record[i], comma, quote); err != nil {
+		if err := w.writeField(record[i], comma, open, closing, w.forced(force, i)); err != nil {
 			w.err = err
 			return err
 		}
 	}
 
-	if w.UseCRLF {
-		if _, err := w.dst.Write([]byte{'\r', '\n'}); err != nil {
-			w.err = err
-			return err
+	return w.writeTerminator()
+}
+
+// tooLong reports whether field exceeds MaxFieldLength.
+func (w *Writer) tooLong(field string) bool {
+	return w.MaxFieldLength > 0 && len(field) > w.MaxFieldLength
+}
+
+// SetColumnQuoting makes the writer always quote the fields at the given column indexes, for
+// example to keep numeric-looking IDs quoted, while other columns follow the Quoting policy.
+// Negative indexes are ignored and a call with no indexes clears the selection. It applies to
+// Write, WriteQuoted, and WriteField alike, but not when Quoting is QuoteNone.
+func (w *Writer) SetColumnQuoting(indices []int) {
+	if w == nil {
+		return
+	}
+	w.quoteColumns = w.quoteColumns[:0]
+	for _, i := range indices {
+		if i < 0 {
+			continue
 		}
-	} else {
-		if err := w.dst.WriteByte('\n'); err != nil {
+		for len(w.quoteColumns) <= i {
+			w.quoteColumns = append(w.quoteColumns, false)
+		}
+		w.quoteColumns[i] = true
+	}
+}
+
+// columnQuoted reports whether SetColumnQuoting selected column i.
+func (w *Writer) columnQuoted(i int) bool {
+	return i < len(w.quoteColumns) && w.quoteColumns[i]
+}
+
+// forced reports whether field i of a record must be quoted because of force or the column
+// selection.
+func (w *Writer) forced(force []bool, i int) bool {
+	return (i < len(force) && force[i]) || w.columnQuoted(i)
+}
+
+// WriteRaw writes line verbatim, without any quoting or escaping, followed by the configured
+// record terminator. The caller is responsible for line being correctly formatted CSV.
+func (w *Writer) WriteRaw(line []byte) error {
+	if w == nil {
+		return errNilWriter
+	}
+	if w.dst == nil {
+		return errWriterNoTarget
+	}
+	if w.err != nil {
+		return w.err
+	}
+	if err := w.writeBOM(); err != nil {
+		return err
+	}
+	if _, err := w.dst.Write(line); err != nil {
+		w.err = err
+		return err
+	}
+	return w.writeTerminator()
+}
+
+// WriteField appends a single field to the record under construction, inserting the
+// delimiter before it when it is not the first field. Finish the record with EndRecord.
+// Write must not be called while a record started by WriteField is still open.
+func (w *Writer) WriteField(field string) error {
+	if w == nil {
+		return errNilWriter
+	}
+	if w.dst == nil {
+		return errWriterNoTarget
+	}
+	if w.err != nil {
+		return w.err
+	}
+
+	comma, open, closing := w.delimiters()
+	force := w.columnQuoted(w.fieldIndex)
+	if w.unquotable(field, comma, open, closing, force) {
+		return ErrUnquotableField
+	}
+	if w.tooLong(field) {
+		return ErrFieldTooLong
+	}
+	if err := w.writeBOM(); err != nil {
+		return err
+	}
+
+	if w.fieldIndex > 0 {
+		if err := w.dst.WriteByte(comma); err != nil {
 			w.err = err
 			return err
 		}
 	}
+	if err := w.writeField(field, comma, open, closing, force); err != nil {
+		w.err = err
+		return err
+	}
+	w.fieldIndex++
 	return nil
 }
 
+// EndRecord terminates the record built with WriteField using the configured newline sequence.
+// Calling it without any preceding WriteField emits an empty line.
+func (w *Writer) EndRecord() error {
+	if w == nil {
+		return errNilWriter
+	}
+	if w.dst == nil {
+		return errWriterNoTarget
+	}
+	if w.err != nil {
+		return w.err
+	}
+	if err := w.writeBOM(); err != nil {
+		return err
+	}
+	w.fieldIndex = 0
+	return w.writeTerminator()
+}
+
 // WriteAll writes multiple records, stopping at the first error.
 func (w *Writer) WriteAll(records [][]string) error {
 	if w == nil {
@@ -116,6 +387,73 @@ func (w *Writer) WriteAll(records [][]string) error {
 	return nil
 }
 
+// WriteAllN writes records like WriteAll, flushes the Writer, and returns the number of bytes
+// that reached the destination for those records, including any byte order mark. Data buffered
+// before the call is flushed first and is not counted.
+func (w *Writer) WriteAllN(records [][]string) (int64, error) {
+	if w == nil {
+		return 0, errNilWriter
+	}
+	if err := w.Flush(); err != nil {
+		return 0, err
+	}
+
+	target := w.out
+	if w.hash != nil {
+		target = io.MultiWriter(w.out, w.hash)
+	}
+	cw := &countingWriter{w: target}
+	w.dst.Reset(cw)
+	defer w.dst.Reset(target)
+
+	for _, record := range records {
+		if err := w.Write(record); err != nil {
+			return cw.n, err
+		}
+	}
+	err := w.Flush()
+	return cw.n, err
+}
+
+// WriteAllContext writes records like WriteAll but checks ctx before each record. On cancellation
+// the records written so far are flushed and ctx.Err() is returned.
+func (w *Writer) WriteAllContext(ctx context.Context, records [][]string) error {
+	if w == nil {
+		return errNilWriter
+	}
+	for _, record := range records {
+		if err := ctx.Err(); err != nil {
+			_ = w.Flush()
+			return err
+		}
+		if err := w.Write(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteMaps writes header followed by one record per map, taking values in header order.
+// Keys missing from a map are written as empty fields and keys absent from header are ignored.
+func (w *Writer) WriteMaps(header []string, rows []map[string]string) error {
+	if w == nil {
+		return errNilWriter
+	}
+	if err := w.Write(header); err != nil {
+		return err
+	}
+	record := make([]string, len(header))
+	for _, row := range rows {
+		for i, name := range header {
+			record[i] = row[name]
+		}
+		if err := w.Write(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Flush flushes pending buffered data to the underlying writer.
 func (w *Writer) Flush() error {
 	if w == nil {
@@ -134,6 +472,36 @@ func (w *Writer) Flush() error {
 	return nil
 }
 
+// Close flushes buffered data and, when the destination implements io.Closer, closes it.
+// Subsequent writes return ErrWriterClosed. Closing again is a no-op that returns the result
+// of the first Close.
+func (w *Writer) Close() error {
+	if w == nil {
+		return errNilWriter
+	}
+	if w.closed {
+		return w.closeErr
+	}
+	if w.dst == nil {
+		return errWriterNoTarget
+	}
+
+	err := w.Flush()
+	if c, ok := w.out.(io.Closer); ok {
+		if cerr := c.Close(); err == nil {
+			err = cerr
+		}
+	}
+	w.closed = true
+	w.closeErr = err
+	if err != nil {
+		w.err = err
+	} else {
+		w.err = ErrWriterClosed
+	}
+	return err
+}
+
 // Error reports the first error encountered by the writer.
 func (w *Writer) Error() error {
 	if w == nil {
@@ -142,50 +510,205 @@ func (w *Writer) Error() error {
 	return w.err
 }
 
-func (w *Writer) writeField(field string, comma, quote byte) error {
-	needsQuote := w.AlwaysQuote
-	if !needsQuote {
-		needsQuote = fieldNeedsQuote(field, comma, quote)
+// countingWriter tallies the bytes successfully written to w.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// writeBOM emits the UTF-8 byte order mark ahead of the first record when WriteBOM is set.
+func (w *Writer) writeBOM() error {
+	if !w.WriteBOM || w.bomWritten {
+		return nil
+	}
+	if _, err := w.dst.WriteString("\ufeff"); err != nil {
+		w.err = err
+		return err
+	}
+	w.bomWritten = true
+	return nil
+}
+
+// writeTerminator emits RecordTerminator or the newline selected by UseCRLF, caching any failure.
+func (w *Writer) writeTerminator() error {
+	if len(w.RecordTerminator) > 0 {
+		if _, err := w.dst.Write(w.RecordTerminator); err != nil {
+			w.err = err
+			return err
+		}
+		return nil
+	}
+	if w.UseCRLF {
+		if _, err := w.dst.Write([]byte{'\r', '\n'}); err != nil {
+			w.err = err
+			return err
+		}
+		return nil
 	}
-	if !needsQuote {
-		_, err := w.dst.WriteString(field)
+	if err := w.dst.WriteByte('\n'); err != nil {
+		w.err = err
 		return err
 	}
-	if err := w.dst.WriteByte(quote); err != nil {
+	return nil
+}
+
+// delimiters returns the delimiter and the quote bytes that open and close a quoted field,
+// applying the defaults for zero settings.
+func (w *Writer) delimiters() (comma, open, closing byte) {
+	comma = w.Comma
+	if comma == 0 {
+		comma = ','
+	}
+	quote := w.Quote
+	if quote == 0 {
+		quote = '"'
+	}
+	open, closing = quote, quote
+	if w.QuoteOpen != 0 {
+		open = w.QuoteOpen
+	}
+	if w.QuoteClose != 0 {
+		closing = w.QuoteClose
+	}
+	return comma, open, closing
+}
+
+// unquotable reports whether field cannot be written under the current settings: it would need
+// quoting while Quoting is QuoteNone, or it contains the close byte of an asymmetric pair and
+// would be quoted.
+func (w *Writer) unquotable(field string, comma, open, closing byte, force bool) bool {
+	if w.Quoting == QuoteNone {
+		return fieldNeedsQuote(field, comma, open, w.RecordTerminator)
+	}
+	if open == closing {
+		return false
+	}
+	field = w.prepareField(field)
+	return strings.IndexByte(field, closing) >= 0 && w.needsQuote(field, comma, open, force)
+}
+
+// prepareField applies SanitizeFormulas and MinFieldWidth to field ahead of quoting.
+func (w *Writer) prepareField(field string) string {
+	if w.SanitizeFormulas && isFormula(field) {
+		prefix := w.FormulaPrefix
+		if prefix == 0 {
+			prefix = '\''
+		}
+		field = string([]byte{prefix}) + field
+	}
+	if w.MinFieldWidth > 0 {
+		if n := utf8.RuneCountInString(field); n < w.MinFieldWidth {
+			field += strings.Repeat(" ", w.MinFieldWidth-n)
+		}
+	}
+	return field
+}
+
+// needsQuote reports whether the prepared field is written quoted under the quoting policy.
+func (w *Writer) needsQuote(field string, comma, open byte, force bool) bool {
+	switch {
+	case w.Quoting == QuoteNone:
+		// Callers reject fields that need quoting before anything is written.
+		return false
+	case w.Quoting == QuoteAll, w.Quoting == QuoteMinimal && w.AlwaysQuote:
+		return true
+	}
+	if force || fieldNeedsQuote(field, comma, open, w.RecordTerminator) {
+		return true
+	}
+	if w.Quoting == QuoteNonNumeric {
+		if _, err := strconv.ParseFloat(field, 64); err != nil {
+			return true
+		}
+	}
+	if w.QuoteLeadingTrailingSpace && hasOuterSpace(field) {
+		return true
+	}
+	return w.QuoteFunc != nil && w.QuoteFunc(field)
+}
+
+func (w *Writer) writeField(field string, comma, open, closing byte, force bool) error {
+	field = w.prepareField(field)
+	if !w.needsQuote(field, comma, open, force) {
+		return w.writeData(field)
+	}
+	if err := w.dst.WriteByte(open); err != nil {
 		return err
 	}
 
 	start := 0
-	for i := 0; i < len(field); i++ {
-		if field[i] == quote {
+	for i := 0; open == closing && i < len(field); i++ {
+		if field[i] == open {
 			if start < i {
-				if _, err := w.dst.WriteString(field[start:i]); err != nil {
+				if err := w.writeData(field[start:i]); err != nil {
 					return err
 				}
 			}
-			if _, err := w.dst.Write([]byte{quote, quote}); err != nil {
+			if _, err := w.dst.Write([]byte{open, open}); err != nil {
 				return err
 			}
 			start = i + 1
 		}
 	}
 	if start < len(field) {
-		if _, err := w.dst.WriteString(field[start:]); err != nil {
+		if err := w.writeData(field[start:]); err != nil {
 			return err
 		}
 	}
-	if err := w.dst.WriteByte(quote); err != nil {
+	if err := w.dst.WriteByte(closing); err != nil {
 		return err
 	}
 	return nil
 }
 
-func fieldNeedsQuote(field string, comma, quote byte) bool {
+// writeData writes field content, passing it through Encoder when one is configured.
+func (w *Writer) writeData(s string) error {
+	if w.Encoder != nil {
+		_, err := w.dst.Write(w.Encoder([]byte(s)))
+		return err
+	}
+	_, err := w.dst.WriteString(s)
+	return err
+}
+
+func fieldNeedsQuote(field string, comma, quote byte, terminator []byte) bool {
 	for i := 0; i < len(field); i++ {
 		switch field[i] {
 		case quote, comma, '\n', '\r':
 			return true
 		}
 	}
+	for _, c := range terminator {
+		if strings.IndexByte(field, c) >= 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// isFormula reports whether field starts with a character spreadsheets treat as a formula.
+func isFormula(field string) bool {
+	if field == "" {
+		return false
+	}
+	switch field[0] {
+	case '=', '+', '-', '@', '\t', '\r':
+		return true
+	}
 	return false
 }
+
+// hasOuterSpace reports whether field starts or ends with a space or tab.
+func hasOuterSpace(field string) bool {
+	if field == "" {
+		return false
+	}
+	first, last := field[0], field[len(field)-1]
+	return first == ' ' || first == '\t' || last == ' ' || last == '\t'
+}
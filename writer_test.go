@@ -2,9 +2,14 @@ package swiftcsv
 
 import (
 	"bytes"
+	"context"
+	"crypto/sha256"
 	"errors"
+	"io"
+	"reflect"
 	"strings"
 	"testing"
+	"unicode/utf8"
 )
 
 func TestWriterWrite(t *testing.T) {
@@ -94,6 +99,148 @@ func TestWriterWrite(t *testing.T) {
 			},
 			want: "a\r\nb\r\n",
 		},
+		{
+			name: "quoteFuncDigits",
+			records: [][]string{
+				{"02134", "Boston", "1,5", ""},
+			},
+			config: func(w *Writer) {
+				w.QuoteFunc = func(field string) bool {
+					if field == "" {
+						return false
+					}
+					for i := 0; i < len(field); i++ {
+						if field[i] < '0' || field[i] > '9' {
+							return false
+						}
+					}
+					return true
+				}
+			},
+			want: "\"02134\",Boston,\"1,5\",\n",
+		},
+		{
+			name: "quoteFuncCannotSuppressMandatoryQuoting",
+			records: [][]string{
+				{"a,b", "say \"x\"", "plain"},
+			},
+			config: func(w *Writer) {
+				w.QuoteFunc = func(string) bool { return false }
+			},
+			want: "\"a,b\",\"say \"\"x\"\"\",plain\n",
+		},
+		{
+			name: "recordSeparatorTerminator",
+			records: [][]string{
+				{"a", "b"},
+				{"c"},
+			},
+			config: func(w *Writer) {
+				w.RecordTerminator = []byte{0x1e}
+			},
+			want: "a,b\x1ec\x1e",
+		},
+		{
+			name: "terminatorByteForcesQuote",
+			records: [][]string{
+				{"a\x1eb", "c"},
+			},
+			config: func(w *Writer) {
+				w.RecordTerminator = []byte{0x1e}
+			},
+			want: "\"a\x1eb\",c\x1e",
+		},
+		{
+			name: "multiByteTerminatorOverridesCRLF",
+			records: [][]string{
+				{"a"},
+				{"b"},
+			},
+			config: func(w *Writer) {
+				w.UseCRLF = true
+				w.RecordTerminator = []byte("<EOR>\n")
+			},
+			want: "a<EOR>\nb<EOR>\n",
+		},
+		{
+			name: "emptyTerminatorKeepsCRLF",
+			records: [][]string{
+				{"a"},
+			},
+			config: func(w *Writer) {
+				w.UseCRLF = true
+				w.RecordTerminator = []byte{}
+			},
+			want: "a\r\n",
+		},
+		{
+			name: "quoteLeadingTrailingSpace",
+			records: [][]string{
+				{" x ", "x ", " x", "\tx", "x y", "", "x"},
+			},
+			config: func(w *Writer) {
+				w.QuoteLeadingTrailingSpace = true
+			},
+			want: "\" x \",\"x \",\" x\",\"\tx\",x y,,x\n",
+		},
+		{
+			name: "sanitizeFormulas",
+			records: [][]string{
+				{"=SUM(A1)", "+1", "-2", "@cmd", "\tx", "\rx", "safe", "a=b", ""},
+			},
+			config: func(w *Writer) {
+				w.SanitizeFormulas = true
+			},
+			want: "'=SUM(A1),'+1,'-2,'@cmd,'\tx,\"'\rx\",safe,a=b,\n",
+		},
+		{
+			name: "sanitizeFormulasCustomPrefixBeforeQuoting",
+			records: [][]string{
+				{"=HYPERLINK(\"x\",\"y\")", "ok"},
+			},
+			config: func(w *Writer) {
+				w.SanitizeFormulas = true
+				w.FormulaPrefix = ' '
+				w.QuoteLeadingTrailingSpace = true
+			},
+			want: "\" =HYPERLINK(\"\"x\"\",\"\"y\"\")\",ok\n",
+		},
+		{
+			name: "formulasUntouchedByDefault",
+			records: [][]string{
+				{"=1+1", "-3"},
+			},
+			want: "=1+1,-3\n",
+		},
+		{
+			name: "leadingTrailingSpaceUnquotedByDefault",
+			records: [][]string{
+				{" x ", "x "},
+			},
+			want: " x ,x \n",
+		},
+		{
+			name: "minFieldWidth",
+			records: [][]string{
+				{"id", "name", "ü", "toolong"},
+				{"1", "a,b", "", "x"},
+			},
+			config: func(w *Writer) {
+				w.MinFieldWidth = 4
+			},
+			want: "id  ,name,ü   ,toolong\n1   ,\"a,b \",    ,x   \n",
+		},
+		{
+			name: "minFieldWidthWithQuoteLeadingTrailingSpace",
+			records: [][]string{
+				{"id", "name"},
+			},
+			config: func(w *Writer) {
+				w.MinFieldWidth = 4
+				w.QuoteLeadingTrailingSpace = true
+			},
+			want: "\"id  \",name\n",
+		},
 	}
 
 	for _, tc := range tests {
@@ -120,6 +267,82 @@ func TestWriterWrite(t *testing.T) {
 	}
 }
 
+// sizeRecorder records the length of every Write call it receives.
+type sizeRecorder struct {
+	sizes []int
+}
+
+func (s *sizeRecorder) Write(p []byte) (int, error) {
+	s.sizes = append(s.sizes, len(p))
+	return len(p), nil
+}
+
+func TestNewWriterSize(t *testing.T) {
+	t.Parallel()
+
+	record := []string{"0123456789", "abcdefghij"}
+	tests := []struct {
+		name      string
+		size      int
+		maxWrite  int
+		minWrites int
+	}{
+		{name: "small", size: 64, maxWrite: 64, minWrites: 30},
+		{name: "large", size: 1 << 16, maxWrite: 1 << 16, minWrites: 1},
+		{name: "defaultForZero", size: 0, maxWrite: defaultBufferSize, minWrites: 2},
+		{name: "defaultForNegative", size: -1, maxWrite: defaultBufferSize, minWrites: 2},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var dst sizeRecorder
+			w := NewWriterSize(&dst, tc.size)
+			for i := 0; i < 100; i++ {
+				if err := w.Write(record); err != nil {
+					t.Fatalf("Write() error = %v", err)
+				}
+			}
+			if err := w.Flush(); err != nil {
+				t.Fatalf("Flush() error = %v", err)
+			}
+
+			total := 0
+			for _, n := range dst.sizes {
+				if n > tc.maxWrite {
+					t.Fatalf("destination received a %d byte write, want at most %d", n, tc.maxWrite)
+				}
+				total += n
+			}
+			if total != 2200 || len(dst.sizes) < tc.minWrites {
+				t.Fatalf("destination received %d bytes in %d writes, want 2200 bytes in at least %d", total, len(dst.sizes), tc.minWrites)
+			}
+			if tc.size > 2200 && len(dst.sizes) != 1 {
+				t.Fatalf("destination received %d writes, want a single write from Flush", len(dst.sizes))
+			}
+		})
+	}
+
+	var first, second sizeRecorder
+	w := NewWriterSize(&first, 32)
+	w.Reset(&second)
+	for i := 0; i < 10; i++ {
+		if err := w.Write(record); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	for _, n := range second.sizes {
+		if n > 32 {
+			t.Fatalf("after Reset, destination received a %d byte write, want at most 32", n)
+		}
+	}
+}
+
 func TestWriterWriteAll(t *testing.T) {
 	t.Parallel()
 
@@ -222,3 +445,976 @@ func TestWriterErrorMethod(t *testing.T) {
 		t.Fatalf("Error() should return %v, got %v", exp, err)
 	}
 }
+
+func TestWriterWriteField(t *testing.T) {
+	t.Parallel()
+
+	records := [][]string{
+		{"alpha", "beta,gamma", "he said \"hi\""},
+		{"multi\nline"},
+		{"", ""},
+	}
+
+	tests := []struct {
+		name   string
+		config func(*Writer)
+	}{
+		{name: "default"},
+		{name: "crlfSemicolon", config: func(w *Writer) {
+			w.Comma = ';'
+			w.UseCRLF = true
+		}},
+		{name: "alwaysQuote", config: func(w *Writer) {
+			w.AlwaysQuote = true
+		}},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var want bytes.Buffer
+			ww := NewWriter(&want)
+			var got bytes.Buffer
+			wf := NewWriter(&got)
+			if tc.config != nil {
+				tc.config(ww)
+				tc.config(wf)
+			}
+
+			for _, rec := range records {
+				if err := ww.Write(rec); err != nil {
+					t.Fatalf("Write() error = %v", err)
+				}
+				for _, field := range rec {
+					if err := wf.WriteField(field); err != nil {
+						t.Fatalf("WriteField() error = %v", err)
+					}
+				}
+				if err := wf.EndRecord(); err != nil {
+					t.Fatalf("EndRecord() error = %v", err)
+				}
+			}
+			if err := ww.Flush(); err != nil {
+				t.Fatalf("Flush() error = %v", err)
+			}
+			if err := wf.Flush(); err != nil {
+				t.Fatalf("Flush() error = %v", err)
+			}
+			if got.String() != want.String() {
+				t.Fatalf("WriteField output mismatch:\n got: %q\nwant: %q", got.String(), want.String())
+			}
+		})
+	}
+}
+
+func TestWriterEndRecordEmpty(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+
+	if err := w.EndRecord(); err != nil {
+		t.Fatalf("EndRecord() error = %v", err)
+	}
+	if err := w.WriteField("a"); err != nil {
+		t.Fatalf("WriteField() error = %v", err)
+	}
+	if err := w.EndRecord(); err != nil {
+		t.Fatalf("EndRecord() error = %v", err)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	if got, want := buf.String(), "\na\n"; got != want {
+		t.Fatalf("unexpected output got %q want %q", got, want)
+	}
+}
+
+func TestWriterWriteBOM(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	w.WriteBOM = true
+
+	if err := w.Write([]string{"name", "price"}); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if err := w.Write([]string{"Widget", "12.50"}); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	if got, want := buf.String(), "\xef\xbb\xbfname,price\nWidget,12.50\n"; got != want {
+		t.Fatalf("unexpected output got %q want %q", got, want)
+	}
+
+	var next bytes.Buffer
+	w.Reset(&next)
+	if err := w.Write([]string{"more"}); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	if got, want := next.String(), "more\n"; got != want {
+		t.Fatalf("BOM repeated after Reset: got %q want %q", got, want)
+	}
+}
+
+func TestWriterWriteBOMRejectedRecord(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	w.WriteBOM = true
+	w.ErrorOnEmptyRecord = true
+	w.MaxFieldLength = 3
+	w.Quoting = QuoteNone
+
+	if err := w.Write(nil); !errors.Is(err, ErrEmptyRecord) {
+		t.Fatalf("Write(nil) error = %v, want %v", err, ErrEmptyRecord)
+	}
+	if err := w.Write([]string{"long"}); !errors.Is(err, ErrFieldTooLong) {
+		t.Fatalf("Write() error = %v, want %v", err, ErrFieldTooLong)
+	}
+	if err := w.Write([]string{"a,b"}); !errors.Is(err, ErrUnquotableField) {
+		t.Fatalf("Write() error = %v, want %v", err, ErrUnquotableField)
+	}
+	if err := w.WriteField("long"); !errors.Is(err, ErrFieldTooLong) {
+		t.Fatalf("WriteField() error = %v, want %v", err, ErrFieldTooLong)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Fatalf("rejected records wrote %q, want nothing", buf.String())
+	}
+
+	if err := w.Write([]string{"ok"}); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	if got, want := buf.String(), "\xef\xbb\xbfok\n"; got != want {
+		t.Fatalf("unexpected output got %q want %q", got, want)
+	}
+}
+
+func TestWriterWriteBOMWriteField(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	w.WriteBOM = true
+
+	if err := w.WriteField("a"); err != nil {
+		t.Fatalf("WriteField() error = %v", err)
+	}
+	if err := w.WriteField("b"); err != nil {
+		t.Fatalf("WriteField() error = %v", err)
+	}
+	if err := w.EndRecord(); err != nil {
+		t.Fatalf("EndRecord() error = %v", err)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	if got, want := buf.String(), "\xef\xbb\xbfa,b\n"; got != want {
+		t.Fatalf("unexpected output got %q want %q", got, want)
+	}
+}
+
+type closeRecorder struct {
+	bytes.Buffer
+	closes   int
+	closeErr error
+}
+
+func (c *closeRecorder) Close() error {
+	c.closes++
+	return c.closeErr
+}
+
+func TestWriterClose(t *testing.T) {
+	t.Parallel()
+
+	dst := &closeRecorder{}
+	w := NewWriter(dst)
+
+	if err := w.Write([]string{"a", "b"}); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if got := dst.String(); got != "a,b\n" {
+		t.Fatalf("Close() did not flush: got %q", got)
+	}
+	if dst.closes != 1 {
+		t.Fatalf("destination closed %d times, want 1", dst.closes)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("second Close() error = %v, want nil", err)
+	}
+	if dst.closes != 1 {
+		t.Fatalf("second Close() reached destination, closes = %d", dst.closes)
+	}
+	if err := w.Write([]string{"c"}); !errors.Is(err, ErrWriterClosed) {
+		t.Fatalf("Write() after Close error = %v, want ErrWriterClosed", err)
+	}
+	if err := w.WriteField("c"); !errors.Is(err, ErrWriterClosed) {
+		t.Fatalf("WriteField() after Close error = %v, want ErrWriterClosed", err)
+	}
+}
+
+func TestWriterCloseErrors(t *testing.T) {
+	t.Parallel()
+
+	t.Run("closerError", func(t *testing.T) {
+		t.Parallel()
+
+		exp := errors.New("close failed")
+		w := NewWriter(&closeRecorder{closeErr: exp})
+		if err := w.Close(); !errors.Is(err, exp) {
+			t.Fatalf("Close() error = %v, want %v", err, exp)
+		}
+		if err := w.Close(); !errors.Is(err, exp) {
+			t.Fatalf("second Close() error = %v, want stored %v", err, exp)
+		}
+	})
+
+	t.Run("flushError", func(t *testing.T) {
+		t.Parallel()
+
+		exp := errors.New("flush failed")
+		w := NewWriter(&flushFailWriter{fail: exp})
+		if err := w.Write([]string{"a"}); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+		if err := w.Close(); !errors.Is(err, exp) {
+			t.Fatalf("Close() error = %v, want %v", err, exp)
+		}
+	})
+
+	t.Run("nonCloser", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		w := NewWriter(&buf)
+		if err := w.Write([]string{"x"}); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+		if err := w.Close(); err != nil {
+			t.Fatalf("Close() error = %v", err)
+		}
+		if buf.String() != "x\n" {
+			t.Fatalf("Close() output = %q, want %q", buf.String(), "x\n")
+		}
+	})
+}
+
+func TestWriterWriteMaps(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+
+	rows := []map[string]string{
+		{"id": "1", "name": "alpha", "note": "first"},
+		{"id": "2", "note": "missing name"},
+		{"id": "3", "name": "gamma", "note": "", "extra": "ignored"},
+		{},
+	}
+	if err := w.WriteMaps([]string{"id", "name", "note"}, rows); err != nil {
+		t.Fatalf("WriteMaps() error = %v", err)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	want := "id,name,note\n1,alpha,first\n2,,missing name\n3,gamma,\n,,\n"
+	if got := buf.String(); got != want {
+		t.Fatalf("unexpected output:\n got: %q\nwant: %q", got, want)
+	}
+}
+
+func TestWriterWriteAllContext(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	// Cancel while the second record is being written, as an aborted request would.
+	w.QuoteFunc = func(field string) bool {
+		if field == "stop" {
+			cancel()
+		}
+		return false
+	}
+
+	records := [][]string{{"a", "1"}, {"stop", "2"}, {"c", "3"}, {"d", "4"}}
+	if err := w.WriteAllContext(ctx, records); !errors.Is(err, context.Canceled) {
+		t.Fatalf("WriteAllContext() error = %v, want context.Canceled", err)
+	}
+	if got, want := buf.String(), "a,1\nstop,2\n"; got != want {
+		t.Fatalf("partial output got %q want flushed %q", got, want)
+	}
+}
+
+func TestWriterWriteAllContextComplete(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	if err := w.WriteAllContext(context.Background(), [][]string{{"a"}, {"b"}}); err != nil {
+		t.Fatalf("WriteAllContext() error = %v", err)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	if got, want := buf.String(), "a\nb\n"; got != want {
+		t.Fatalf("unexpected output got %q want %q", got, want)
+	}
+}
+
+func TestWriterWriteRaw(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	w.UseCRLF = true
+
+	if err := w.Write([]string{"id", "note"}); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if err := w.WriteRaw([]byte(`1,"already ""quoted"", verbatim"`)); err != nil {
+		t.Fatalf("WriteRaw() error = %v", err)
+	}
+	if err := w.Write([]string{"2", "a,b"}); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if err := w.WriteRaw(nil); err != nil {
+		t.Fatalf("WriteRaw() error = %v", err)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	want := "id,note\r\n1,\"already \"\"quoted\"\", verbatim\"\r\n2,\"a,b\"\r\n\r\n"
+	if got := buf.String(); got != want {
+		t.Fatalf("unexpected output:\n got: %q\nwant: %q", got, want)
+	}
+}
+
+func TestWriterWriteAllN(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	w.WriteBOM = true
+	if err := w.Write([]string{"id", "note"}); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	before := len("\ufeffid,note\n")
+
+	records := [][]string{
+		{"1", "plain"},
+		{"2", "has,comma"},
+		{"3", strings.Repeat("x", 3*defaultBufferSize)},
+	}
+	n, err := w.WriteAllN(records)
+	if err != nil {
+		t.Fatalf("WriteAllN() error = %v", err)
+	}
+	if want := int64(buf.Len() - before); n != want {
+		t.Fatalf("WriteAllN() = %d bytes, want %d", n, want)
+	}
+
+	if err := w.Write([]string{"tail"}); err != nil {
+		t.Fatalf("Write() after WriteAllN error = %v", err)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	if !strings.HasSuffix(buf.String(), "\ntail\n") {
+		t.Fatalf("output after WriteAllN does not end with tail record: %q", buf.String())
+	}
+}
+
+func TestWriterEncoderRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	toLatin1 := func(b []byte) []byte {
+		out := make([]byte, 0, len(b))
+		for _, r := range string(b) {
+			out = append(out, byte(r))
+		}
+		return out
+	}
+	fromLatin1 := func(b []byte) []byte {
+		out := make([]byte, 0, 2*len(b))
+		for _, c := range b {
+			out = utf8.AppendRune(out, rune(c))
+		}
+		return out
+	}
+
+	records := [][]string{
+		{"café", `say "ñ"`, "a,ü"},
+		{"plain", "", "µ"},
+	}
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	w.Encoder = toLatin1
+	if err := w.WriteAll(records); err != nil {
+		t.Fatalf("WriteAll() error = %v", err)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	wantRaw := "caf\xe9,\"say \"\"\xf1\"\"\",\"a,\xfc\"\nplain,,\xb5\n"
+	if got := buf.String(); got != wantRaw {
+		t.Fatalf("encoded output = %q, want %q", got, wantRaw)
+	}
+
+	r := NewReader(&buf)
+	r.Decoder = fromLatin1
+	got, err := r.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, records) {
+		t.Fatalf("round trip = %#v, want %#v", got, records)
+	}
+}
+
+func TestNewWriterHash(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	h := sha256.New()
+	w := NewWriterHash(&buf, h)
+	w.WriteBOM = true
+
+	if err := w.Write([]string{"id", "note"}); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if _, err := w.WriteAllN([][]string{{"1", strings.Repeat("x", 2*defaultBufferSize)}, {"2", "a,b"}}); err != nil {
+		t.Fatalf("WriteAllN() error = %v", err)
+	}
+	if err := w.Write([]string{"3", `"q"`}); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	want := sha256.Sum256(buf.Bytes())
+	if got := h.Sum(nil); !bytes.Equal(got, want[:]) {
+		t.Fatalf("hash = %x, want %x", got, want)
+	}
+
+	h.Reset()
+	var next bytes.Buffer
+	w.Reset(&next)
+	if err := w.Write([]string{"after", "reset"}); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	want = sha256.Sum256(next.Bytes())
+	if got := h.Sum(nil); !bytes.Equal(got, want[:]) {
+		t.Fatalf("hash after Reset = %x, want %x", got, want)
+	}
+}
+
+func TestWriterWriteQuoted(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+
+	if err := w.WriteQuoted([]string{"a", "b,c", "d", "e"}, []bool{true, false, false}); err != nil {
+		t.Fatalf("WriteQuoted() error = %v", err)
+	}
+	if err := w.WriteQuoted([]string{"", `q"x`}, nil); err != nil {
+		t.Fatalf("WriteQuoted() error = %v", err)
+	}
+	if err := w.WriteQuoted([]string{"", "z"}, []bool{true, true, true}); err != nil {
+		t.Fatalf("WriteQuoted() error = %v", err)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	want := "\"a\",\"b,c\",d,e\n,\"q\"\"x\"\n\"\",\"z\"\n"
+	if got := buf.String(); got != want {
+		t.Fatalf("unexpected output:\n got: %q\nwant: %q", got, want)
+	}
+}
+
+func TestWriterWriteQuotedPreservesReaderStyle(t *testing.T) {
+	t.Parallel()
+
+	const input = "\"id\",name,\"\"\n\"1\",plain,x\n"
+
+	r := NewReader(strings.NewReader(input))
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Read() error = %v", err)
+		}
+		quoted := make([]bool, len(record))
+		for i := range record {
+			quoted[i] = r.FieldQuoted(i)
+		}
+		if err := w.WriteQuoted(record, quoted); err != nil {
+			t.Fatalf("WriteQuoted() error = %v", err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	if got := buf.String(); got != input {
+		t.Fatalf("rewritten output = %q, want %q", got, input)
+	}
+}
+
+func TestWriterQuoting(t *testing.T) {
+	t.Parallel()
+
+	record := []string{"12", "-3.5e2", "abc", "", "a,b"}
+
+	tests := []struct {
+		name   string
+		config func(*Writer)
+		want   string
+	}{
+		{name: "minimal", config: func(w *Writer) {}, want: "12,-3.5e2,abc,,\"a,b\"\n"},
+		{name: "all", config: func(w *Writer) { w.Quoting = QuoteAll }, want: "\"12\",\"-3.5e2\",\"abc\",\"\",\"a,b\"\n"},
+		{name: "alwaysQuoteMapsToAll", config: func(w *Writer) { w.AlwaysQuote = true }, want: "\"12\",\"-3.5e2\",\"abc\",\"\",\"a,b\"\n"},
+		{name: "nonNumeric", config: func(w *Writer) { w.Quoting = QuoteNonNumeric }, want: "12,-3.5e2,\"abc\",\"\",\"a,b\"\n"},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+			w := NewWriter(&buf)
+			tc.config(w)
+			if err := w.Write(record); err != nil {
+				t.Fatalf("Write() error = %v", err)
+			}
+			if err := w.Flush(); err != nil {
+				t.Fatalf("Flush() error = %v", err)
+			}
+			if got := buf.String(); got != tc.want {
+				t.Fatalf("unexpected output:\n got: %q\nwant: %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestWriterQuoteNone(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	w.Quoting = QuoteNone
+	w.AlwaysQuote = true
+
+	if err := w.Write([]string{"plain", " spaced ", ""}); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	for _, bad := range []string{"a,b", `say "hi"`, "two\nlines", "cr\r"} {
+		if err := w.Write([]string{"ok", bad}); !errors.Is(err, ErrUnquotableField) {
+			t.Fatalf("Write(%q) error = %v, want ErrUnquotableField", bad, err)
+		}
+	}
+	if err := w.WriteField("a,b"); !errors.Is(err, ErrUnquotableField) {
+		t.Fatalf("WriteField() error = %v, want ErrUnquotableField", err)
+	}
+	if err := w.Write([]string{"after", "error"}); err != nil {
+		t.Fatalf("Write() after rejected record error = %v", err)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	want := "plain, spaced ,\nafter,error\n"
+	if got := buf.String(); got != want {
+		t.Fatalf("unexpected output:\n got: %q\nwant: %q", got, want)
+	}
+}
+
+func TestWriterCustomQuoteIgnoresDoubleQuote(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		quote   byte
+		comma   byte
+		quoting QuoteStyle
+		field   string
+		want    string
+	}{
+		{name: "singleQuoteDoubleQuote", quote: '\'', field: `say "hi"`, want: `say "hi"`},
+		{name: "singleQuoteOnlyDoubleQuote", quote: '\'', field: `"`, want: `"`},
+		{name: "singleQuoteLeadingDoubleQuote", quote: '\'', field: `"x"`, want: `"x"`},
+		{name: "singleQuoteOwnQuote", quote: '\'', field: `it's "ok"`, want: `'it''s "ok"'`},
+		{name: "singleQuoteComma", quote: '\'', field: `"a",b`, want: `'"a",b'`},
+		{name: "singleQuoteSemicolon", quote: '\'', comma: ';', field: `"a",b`, want: `"a",b`},
+		{name: "pipeQuote", quote: '|', field: `"x"`, want: `"x"`},
+		{name: "pipeQuoteOwnQuote", quote: '|', field: `a|"b"`, want: `|a||"b"|`},
+		{name: "quoteNone", quote: '\'', quoting: QuoteNone, field: `say "hi"`, want: `say "hi"`},
+		{name: "nonNumeric", quote: '\'', quoting: QuoteNonNumeric, field: `"`, want: `'"'`},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			for _, useField := range []bool{false, true} {
+				var buf bytes.Buffer
+				w := NewWriter(&buf)
+				w.Quote = tc.quote
+				w.Quoting = tc.quoting
+				if tc.comma != 0 {
+					w.Comma = tc.comma
+				}
+				var err error
+				if useField {
+					if err = w.WriteField(tc.field); err == nil {
+						err = w.EndRecord()
+					}
+				} else {
+					err = w.Write([]string{tc.field})
+				}
+				if err != nil {
+					t.Fatalf("write error = %v (WriteField = %t)", err, useField)
+				}
+				if err := w.Flush(); err != nil {
+					t.Fatalf("Flush() error = %v", err)
+				}
+				if got := buf.String(); got != tc.want+"\n" {
+					t.Fatalf("wrote %q, want %q (WriteField = %t)", got, tc.want+"\n", useField)
+				}
+
+				r := NewReader(strings.NewReader(buf.String()))
+				r.Quote = tc.quote
+				if tc.comma != 0 {
+					r.Comma = tc.comma
+				}
+				record, err := r.Read()
+				if err != nil {
+					t.Fatalf("Read() error = %v", err)
+				}
+				if len(record) != 1 || record[0] != tc.field {
+					t.Fatalf("Read() = %q, want [%q]", record, tc.field)
+				}
+			}
+		})
+	}
+}
+
+func TestWriterRecordTerminatorRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	records := [][]string{{"a\x1eb", "plain"}, {"line\nbreak", "\x1e"}, {"", "end"}}
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	w.RecordTerminator = []byte{0x1e}
+	if err := w.WriteAll(records); err != nil {
+		t.Fatalf("WriteAll() error = %v", err)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	r := NewReader(&buf)
+	r.RecordTerminators = []byte{0x1e}
+	got, err := r.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, records) {
+		t.Fatalf("round trip = %q, want %q", got, records)
+	}
+
+	w = NewWriter(&buf)
+	w.RecordTerminator = []byte{0x1e}
+	w.Quoting = QuoteNone
+	if err := w.Write([]string{"a\x1eb"}); !errors.Is(err, ErrUnquotableField) {
+		t.Fatalf("Write() with QuoteNone error = %v, want %v", err, ErrUnquotableField)
+	}
+}
+
+func TestWriterTrimTrailingEmpty(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		record []string
+		want   string
+	}{
+		{name: "none", record: []string{"a", "b"}, want: "a,b\n"},
+		{name: "one", record: []string{"a", "b", ""}, want: "a,b\n"},
+		{name: "several", record: []string{"a", "b", "", ""}, want: "a,b\n"},
+		{name: "interior", record: []string{"a", "", "b", "", ""}, want: "a,,b\n"},
+		{name: "leading", record: []string{"", "a", ""}, want: ",a\n"},
+		{name: "allEmpty", record: []string{"", "", ""}, want: "\n"},
+		{name: "noFields", record: nil, want: "\n"},
+		{name: "spaceIsNotEmpty", record: []string{"a", " "}, want: "a, \n"},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+			w := NewWriter(&buf)
+			w.TrimTrailingEmpty = true
+			if err := w.Write(tc.record); err != nil {
+				t.Fatalf("Write() error = %v", err)
+			}
+			if err := w.Flush(); err != nil {
+				t.Fatalf("Flush() error = %v", err)
+			}
+			if got := buf.String(); got != tc.want {
+				t.Fatalf("Write(%q) wrote %q, want %q", tc.record, got, tc.want)
+			}
+		})
+	}
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	w.TrimTrailingEmpty = true
+	if err := w.WriteQuoted([]string{"a", "", ""}, []bool{true, true, true}); err != nil {
+		t.Fatalf("WriteQuoted() error = %v", err)
+	}
+	w.TrimTrailingEmpty = false
+	if err := w.Write([]string{"a", "", ""}); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	if got, want := buf.String(), "\"a\"\na,,\n"; got != want {
+		t.Fatalf("unexpected output:\n got: %q\nwant: %q", got, want)
+	}
+}
+
+func TestWriterErrorOnEmptyRecord(t *testing.T) {
+	t.Parallel()
+
+	for _, strict := range []bool{false, true} {
+		var buf bytes.Buffer
+		w := NewWriter(&buf)
+		w.ErrorOnEmptyRecord = strict
+		w.WriteBOM = true
+		for _, record := range [][]string{nil, {}} {
+			err := w.Write(record)
+			if strict && !errors.Is(err, ErrEmptyRecord) {
+				t.Fatalf("strict Write(%#v) error = %v, want %v", record, err, ErrEmptyRecord)
+			}
+			if !strict && err != nil {
+				t.Fatalf("Write(%#v) error = %v", record, err)
+			}
+		}
+		if err := w.WriteQuoted(nil, nil); strict != errors.Is(err, ErrEmptyRecord) {
+			t.Fatalf("WriteQuoted(nil) error = %v with ErrorOnEmptyRecord = %t", err, strict)
+		}
+		if err := w.Write([]string{""}); err != nil {
+			t.Fatalf("Write([\"\"]) error = %v", err)
+		}
+		if err := w.Flush(); err != nil {
+			t.Fatalf("Flush() error = %v", err)
+		}
+		want := "\ufeff\n\n\n\n"
+		if strict {
+			want = "\ufeff\n"
+		}
+		if got := buf.String(); got != want {
+			t.Fatalf("ErrorOnEmptyRecord = %t wrote %q, want %q", strict, got, want)
+		}
+	}
+}
+
+func TestWriterMaxFieldLength(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		record  []string
+		want    string
+		wantErr error
+	}{
+		{name: "below", record: []string{"ab", "c"}, want: "ab,c\n"},
+		{name: "at", record: []string{"abc", "x,y"}, want: "abc,\"x,y\"\n"},
+		{name: "above", record: []string{"ab", "abcd"}, wantErr: ErrFieldTooLong},
+		{name: "multiByte", record: []string{"é", "éé"}, wantErr: ErrFieldTooLong},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+			w := NewWriter(&buf)
+			w.MaxFieldLength = 3
+			if err := w.Write(tc.record); !errors.Is(err, tc.wantErr) {
+				t.Fatalf("Write() error = %v, want %v", err, tc.wantErr)
+			}
+			if err := w.Flush(); err != nil {
+				t.Fatalf("Flush() error = %v", err)
+			}
+			if got := buf.String(); got != tc.want {
+				t.Fatalf("Write(%q) wrote %q, want %q", tc.record, got, tc.want)
+			}
+		})
+	}
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	w.MaxFieldLength = 2
+	if err := w.WriteField("abc"); !errors.Is(err, ErrFieldTooLong) {
+		t.Fatalf("WriteField() error = %v, want %v", err, ErrFieldTooLong)
+	}
+	w.MaxFieldLength = 0
+	if err := w.Write([]string{strings.Repeat("x", 10)}); err != nil {
+		t.Fatalf("Write() with no limit error = %v", err)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	if got, want := buf.String(), "xxxxxxxxxx\n"; got != want {
+		t.Fatalf("unexpected output:\n got: %q\nwant: %q", got, want)
+	}
+}
+
+func TestWriterSetColumnQuoting(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	w.SetColumnQuoting([]int{0, -1})
+	if err := w.WriteAll([][]string{
+		{"id", "name"},
+		{"007", "Bond"},
+		{"42", "a,b"},
+		{""},
+	}); err != nil {
+		t.Fatalf("WriteAll() error = %v", err)
+	}
+	for _, field := range []string{"9", "x", "y"} {
+		if err := w.WriteField(field); err != nil {
+			t.Fatalf("WriteField() error = %v", err)
+		}
+	}
+	if err := w.EndRecord(); err != nil {
+		t.Fatalf("EndRecord() error = %v", err)
+	}
+	if err := w.WriteField("10"); err != nil {
+		t.Fatalf("WriteField() error = %v", err)
+	}
+	if err := w.EndRecord(); err != nil {
+		t.Fatalf("EndRecord() error = %v", err)
+	}
+	w.SetColumnQuoting(nil)
+	if err := w.Write([]string{"11", "z"}); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	want := "\"id\",name\n\"007\",Bond\n\"42\",\"a,b\"\n\"\"\n\"9\",x,y\n\"10\"\n11,z\n"
+	if got := buf.String(); got != want {
+		t.Fatalf("unexpected output:\n got: %q\nwant: %q", got, want)
+	}
+}
+
+func TestWriterQuotePair(t *testing.T) {
+	t.Parallel()
+
+	records := [][]string{
+		{"a,b", "c"},
+		{"x\ny", "[lead"},
+		{"tail]", "say \"hi\""},
+	}
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	w.QuoteOpen, w.QuoteClose = '[', ']'
+	if err := w.WriteAll(records); err != nil {
+		t.Fatalf("WriteAll() error = %v", err)
+	}
+	if err := w.Write([]string{"ok", "a,]"}); !errors.Is(err, ErrUnquotableField) {
+		t.Fatalf("Write() with close byte error = %v, want ErrUnquotableField", err)
+	}
+	if err := w.WriteField("x]\n"); !errors.Is(err, ErrUnquotableField) {
+		t.Fatalf("WriteField() with close byte error = %v, want ErrUnquotableField", err)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	want := "[a,b],c\n[x\ny],[[lead]\ntail],say \"hi\"\n"
+	if got := buf.String(); got != want {
+		t.Fatalf("unexpected output:\n got: %q\nwant: %q", got, want)
+	}
+
+	r := NewReader(&buf)
+	r.QuoteOpen, r.QuoteClose = '[', ']'
+	got, err := r.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, records) {
+		t.Fatalf("round trip mismatch:\n got: %#v\nwant: %#v", got, records)
+	}
+}
+
+func TestWriterQuotePairQuoteAll(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	w.Quoting = QuoteAll
+	w.QuoteOpen, w.QuoteClose = '<', '>'
+	if err := w.Write([]string{"a", "b>"}); !errors.Is(err, ErrUnquotableField) {
+		t.Fatalf("Write() error = %v, want ErrUnquotableField", err)
+	}
+	if err := w.Write([]string{"a", "<b"}); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	w.QuoteOpen, w.QuoteClose = '\'', '\''
+	if err := w.Write([]string{"it's"}); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	if got, want := buf.String(), "<a>,<<b>\n'it''s'\n"; got != want {
+		t.Fatalf("unexpected output:\n got: %q\nwant: %q", got, want)
+	}
+}